@@ -17,6 +17,7 @@ package jazz
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 
 	"github.com/beevik/etree"
@@ -29,6 +30,18 @@ var CCMErrorEmptyResponse = errors.New("empty response XML -> item maybe deleted
 // CCMApplication interface
 type CCMApplication struct {
 	client *Client
+
+	// Cache used for loaded objects (nil = no caching)
+	Cache *CCMCache
+
+	// DiskCache used to persist loaded objects (nil = no caching)
+	DiskCache *CCMDiskCache
+}
+
+// ccmEntry is a reference to an object in a specific state
+type ccmEntry struct {
+	ItemId  string
+	StateId string
 }
 
 // Name of application
@@ -55,13 +68,62 @@ func CCMList[T CCMObject](ctx context.Context, ccm *CCMApplication, filter CCMFi
 
 // CCMListChan object of the given type returned via a channel
 func CCMListChan[T CCMObject](ctx context.Context, ccm *CCMApplication, filter CCMFilter, results chan T) error {
+	sortFields := filter.sortFields()
+	if len(sortFields) == 0 {
+		return ccmListChan[T](ctx, ccm, filter, results)
+	}
+
+	spec := (*new(T)).Spec()
+	for _, field := range sortFields {
+		_, err := spec.structField(field.field)
+		if err != nil {
+			return fmt.Errorf("invalid sort order: %w", err)
+		}
+	}
+
+	// sorting requires all objects before the first one is returned
+	list, err := Chan2List[T](func(ch chan T) error {
+		return ccmListChan[T](ctx, ccm, filter, ch)
+	})
+	if err != nil {
+		return err
+	}
+	ccmSortObjects(list, sortFields)
+	for _, obj := range list {
+		results <- obj
+	}
+	return nil
+}
+
+// ccmListChan loads the objects of the given filter without sorting
+func ccmListChan[T CCMObject](ctx context.Context, ccm *CCMApplication, filter CCMFilter, results chan T) error {
+	spec := (*new(T)).Spec()
+
+	// selected fields are loaded directly from the list
+	if len(filter.selectedFields()) > 0 {
+		url, err := spec.ListURL(filter)
+		if err != nil {
+			return err
+		}
+		return ccm.listElements(ctx, spec, url, func(element *etree.Element) error {
+			var obj T
+			err := spec.Load(ccm, reflect.ValueOf(&obj), element)
+			if err != nil {
+				return err
+			}
+			results <- obj
+			return nil
+		})
+	}
+
 	// load object returned by list
-	requestChan := make(chan string, 100*2)
-	g := new(errgroup.Group)
+	requestChan := make(chan ccmEntry, 100*2)
+	g, gctx := errgroup.WithContext(ctx)
 	for i := 0; i < ccm.client.Worker; i++ {
 		g.Go(func() error {
-			for id := range requestChan {
-				obj, err := CCMGet[T](ctx, ccm, id)
+			for entry := range requestChan {
+				var obj T
+				err := ccm.getState(gctx, spec, reflect.ValueOf(&obj), entry.ItemId, entry.StateId)
 				if err != nil {
 					return err
 				} else {
@@ -72,30 +134,68 @@ func CCMListChan[T CCMObject](ctx context.Context, ccm *CCMApplication, filter C
 		})
 	}
 
-	err := CCMListEntryChan[T](ctx, ccm, filter, requestChan)
-	if err != nil {
-		return err
-	}
+	err := ccm.listEntries(gctx, spec, filter, requestChan)
 
-	// stop background worker and wait for work is done
+	// stop background worker and wait for work is done (errors of the
+	// worker are the reason if the list was canceled)
 	close(requestChan)
-	err = g.Wait()
+	if workerErr := g.Wait(); workerErr != nil {
+		return workerErr
+	}
 	return err
 }
 
 // CCMListEntryChan queries only the references of objects (without loading)
 func CCMListEntryChan[T CCMObject](ctx context.Context, ccm *CCMApplication, filter CCMFilter, results chan string) error {
-	spec := (*new(T)).Spec()
+	entryChan := make(chan ccmEntry, 100)
+	g := new(errgroup.Group)
+	g.Go(func() error {
+		for entry := range entryChan {
+			results <- entry.ItemId
+		}
+		return nil
+	})
+
+	err := ccm.listEntries(ctx, (*new(T)).Spec(), filter, entryChan)
+	close(entryChan)
+	_ = g.Wait()
+	return err
+}
 
-	// get initial URL request
-	url, err := spec.ListURL(filter)
+// listEntries queries the item and state IDs of objects (without loading)
+func (a *CCMApplication) listEntries(ctx context.Context, spec *CCMObjectSpec, filter CCMFilter, results chan ccmEntry) error {
+	// get initial URL request (selected fields are not required)
+	url, err := spec.ListURL(filter.without(ccmFilterSelect))
 	if err != nil {
 		return err
 	}
 
+	return a.listElements(ctx, spec, url, func(element *etree.Element) error {
+		itemId := element.SelectElement("itemId")
+		if itemId == nil {
+			return nil
+		}
+
+		var stateId string
+		if stateElement := element.SelectElement("stateId"); stateElement != nil {
+			stateId = stateElement.Text()
+		}
+
+		select {
+		case results <- ccmEntry{ItemId: itemId.Text(), StateId: stateId}:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// listElements requests the list with the given URL and passes every
+// object element to handle
+func (a *CCMApplication) listElements(ctx context.Context, spec *CCMObjectSpec, url string, handle func(element *etree.Element) error) error {
 	// request list until last page reached
 	for url != "" {
-		resp, root, err := ccm.client.getEtree(ctx, url, "application/xml", //nolint:bodyclose
+		resp, root, err := a.client.getEtree(ctx, url, "application/xml", //nolint:bodyclose
 			"failed get element list", 0)
 		if err != nil {
 			return err
@@ -104,10 +204,12 @@ func CCMListEntryChan[T CCMObject](ctx context.Context, ccm *CCMApplication, fil
 			return ccmResponse2error(root)
 		}
 
-		// extract item IDs from result
-		entries := root.FindElements(spec.ElementID + "/itemId")
+		entries := root.FindElements(spec.ElementID)
 		for _, entry := range entries {
-			results <- entry.Text()
+			err = handle(entry)
+			if err != nil {
+				return err
+			}
 		}
 
 		if len(entries) >= 100 {
@@ -134,23 +236,107 @@ func CCMGetFilter[T CCMObject](ctx context.Context, ccm *CCMApplication, filter
 }
 
 func (a *CCMApplication) get(ctx context.Context, spec *CCMObjectSpec, value reflect.Value, id string) error {
+	return a.getState(ctx, spec, value, id, "")
+}
+
+// getState loads the object with the given ID. If the state ID is known
+// cached objects of other states are ignored.
+func (a *CCMApplication) getState(ctx context.Context, spec *CCMObjectSpec, value reflect.Value, id, stateId string) error {
+	var element *etree.Element
+	var err error
+	if a.Cache != nil {
+		// drop outdated object from cache
+		if stateId != "" {
+			a.Cache.Validate(id, stateId)
+		}
+
+		element, err = a.Cache.get(ctx, spec, id, func(ctx context.Context) (*etree.Element, error) {
+			return a.loadElement(ctx, spec, id, stateId)
+		})
+	} else {
+		element, err = a.loadElement(ctx, spec, id, stateId)
+	}
+	if err != nil {
+		return err
+	}
+
+	return spec.Load(a, value, element)
+}
+
+// loadElement of the object with the given ID from the disk cache or server
+func (a *CCMApplication) loadElement(ctx context.Context, spec *CCMObjectSpec, id, stateId string) (*etree.Element, error) {
+	if a.DiskCache == nil {
+		return a.getElement(ctx, spec, id)
+	}
+
+	// only request the current state if not known
+	if stateId == "" {
+		var err error
+		stateId, err = a.getStateId(ctx, spec, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	element, err := a.DiskCache.load(spec, id, stateId)
+	if err != nil {
+		a.client.Logger.Sugar().Debugf("failed to read %s from disk cache: %s", id, err)
+	}
+	if element != nil {
+		return element, nil
+	}
+
+	element, err = a.getElement(ctx, spec, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.DiskCache.store(spec, id, element)
+	if err != nil {
+		a.client.Logger.Sugar().Debugf("failed to write %s to disk cache: %s", id, err)
+	}
+	return element, nil
+}
+
+// getStateId returns the current state ID of the object with the given ID
+func (a *CCMApplication) getStateId(ctx context.Context, spec *CCMObjectSpec, id string) (string, error) {
+	resp, root, err := a.client.getEtree(ctx,
+		spec.StateURL(id),
+		"application/xml",
+		"failed get state of element "+id, 0)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != 200 {
+		return "", ccmResponse2error(root)
+	}
+
+	element := root.FindElement(spec.ElementID + "/stateId")
+	if element == nil {
+		return "", CCMErrorEmptyResponse
+	}
+	return element.Text(), nil
+}
+
+// getElement of the object with the given ID from the server
+func (a *CCMApplication) getElement(ctx context.Context, spec *CCMObjectSpec, id string) (*etree.Element, error) {
 	resp, root, err := a.client.getEtree(ctx,
 		spec.GetURL(id),
 		"application/xml",
 		"failed get element "+id, 0)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if resp.StatusCode != 200 {
-		return ccmResponse2error(root)
+		return nil, ccmResponse2error(root)
 	}
 
 	// catch empty elements
-	if len(root.ChildElements()) == 0 {
-		return CCMErrorEmptyResponse
+	element := root.FindElement(spec.ElementID)
+	if element == nil {
+		return nil, CCMErrorEmptyResponse
 	}
-
-	return spec.Load(a, value, root.FindElement(spec.ElementID))
+	return element, nil
 }
 
 func ccmResponse2error(root *etree.Element) error {
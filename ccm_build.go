@@ -0,0 +1,206 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"strconv"
+)
+
+// build REST service (not part of the reportable REST API)
+const ccmBuildService = "ccm/service/com.ibm.team.build.internal.common.rest.IBuildRestService/"
+
+// States of a CCMBuildResult
+const (
+	CCMBuildStateNotStarted = "NOT_STARTED"
+	CCMBuildStateInProgress = "IN_PROGRESS"
+	CCMBuildStateCompleted  = "COMPLETED"
+	CCMBuildStateIncomplete = "INCOMPLETE"
+	CCMBuildStateCanceled   = "CANCELED"
+)
+
+// Status of a CCMBuildResult
+const (
+	CCMBuildStatusOk      = "OK"
+	CCMBuildStatusInfo    = "INFO"
+	CCMBuildStatusWarning = "WARNING"
+	CCMBuildStatusError   = "ERROR"
+)
+
+// CCMBuild is used to publish the result of a build executed outside of Jazz
+type CCMBuild struct {
+	// ItemId of the build result
+	ItemId string
+
+	// ccm Application instance used for interactions with the server
+	ccm *CCMApplication
+}
+
+// StartBuild creates a new build result in state IN_PROGRESS for the given
+// build definition
+func (a *CCMApplication) StartBuild(ctx context.Context, definition *CCMBuildDefinition, label string, personal bool) (*CCMBuild, error) {
+	var value struct {
+		ItemId string `json:"itemId"`
+	}
+	err := a.servicePost(ctx, ccmBuildService+"postCreateBuildResult", url.Values{
+		"buildDefinitionItemId": {definition.ItemId},
+		"label":                 {label},
+		"personalBuild":         {strconv.FormatBool(personal)},
+		"buildState":            {CCMBuildStateInProgress},
+	}, &value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create build result: %w", err)
+	}
+	if value.ItemId == "" {
+		return nil, errors.New("failed to create build result: no item ID returned")
+	}
+
+	return &CCMBuild{
+		ItemId: value.ItemId,
+		ccm:    a,
+	}, nil
+}
+
+// update sends the given values for the build result to the server
+func (b *CCMBuild) update(ctx context.Context, method string, values url.Values) error {
+	values.Set("buildResultItemId", b.ItemId)
+	err := b.ccm.servicePost(ctx, ccmBuildService+method, values, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update build result %s: %w", b.ItemId, err)
+	}
+	return nil
+}
+
+// SetLabel of the build result
+func (b *CCMBuild) SetLabel(ctx context.Context, label string) error {
+	return b.update(ctx, "postUpdateBuildResult", url.Values{
+		"label": {label},
+	})
+}
+
+// SetStatus of the build result (see CCMBuildStatus*)
+func (b *CCMBuild) SetStatus(ctx context.Context, status string) error {
+	return b.update(ctx, "postUpdateBuildResult", url.Values{
+		"buildStatus": {status},
+	})
+}
+
+// SetState of the build result (see CCMBuildState*)
+func (b *CCMBuild) SetState(ctx context.Context, state string) error {
+	return b.update(ctx, "postUpdateBuildResult", url.Values{
+		"buildState": {state},
+	})
+}
+
+// AddLog file to the build result
+func (b *CCMBuild) AddLog(ctx context.Context, name string, reader io.Reader) error {
+	return b.addFileContribution(ctx, "com.ibm.team.build.common.model.IBuildResultContribution.log", name, reader)
+}
+
+// AddDownload file to the build result
+func (b *CCMBuild) AddDownload(ctx context.Context, name string, reader io.Reader) error {
+	return b.addFileContribution(ctx, "com.ibm.team.build.common.model.IBuildResultContribution.artifact", name, reader)
+}
+
+// AddUnitTestResult to the build result
+func (b *CCMBuild) AddUnitTestResult(ctx context.Context, result *CCMUnitTestResult) error {
+	return b.update(ctx, "postAddContribution", url.Values{
+		"extendedContributionTypeId": {"com.ibm.team.build.junit"},
+		"label":                      {result.Component},
+		"component":                  {result.Component},
+		"tests":                      {strconv.FormatInt(result.Tests, 10)},
+		"failures":                   {strconv.FormatInt(result.Failures, 10)},
+		"errors":                     {strconv.FormatInt(result.Errors, 10)},
+	})
+}
+
+// AddCompilationResult to the build result
+func (b *CCMBuild) AddCompilationResult(ctx context.Context, result *CCMCompilationResult) error {
+	return b.update(ctx, "postAddContribution", url.Values{
+		"extendedContributionTypeId": {"com.ibm.team.build.compile"},
+		"label":                      {result.Component},
+		"component":                  {result.Component},
+		"errors":                     {strconv.FormatInt(result.Errors, 10)},
+		"warnings":                   {strconv.FormatInt(result.Warnings, 10)},
+	})
+}
+
+// LinkWorkItems to the build result (shown as "Work Items Included")
+func (b *CCMBuild) LinkWorkItems(ctx context.Context, workItems ...*CCMWorkItem) error {
+	values := make(url.Values)
+	for _, workItem := range workItems {
+		values.Add("workItemItemId", workItem.ItemId)
+	}
+	return b.update(ctx, "postLinkWorkItems", values)
+}
+
+// Complete the build with the given status (see CCMBuildStatus*)
+func (b *CCMBuild) Complete(ctx context.Context, status string) error {
+	return b.update(ctx, "postUpdateBuildResult", url.Values{
+		"buildStatus": {status},
+		"buildState":  {CCMBuildStateCompleted},
+	})
+}
+
+// Result returns the current build result from the server
+func (b *CCMBuild) Result(ctx context.Context) (*CCMBuildResult, error) {
+	return CCMGet[*CCMBuildResult](ctx, b.ccm, b.ItemId)
+}
+
+// addFileContribution uploads a file as contribution of the build result
+func (b *CCMBuild) addFileContribution(ctx context.Context, contributionType, name string, fileReader io.Reader) error {
+	// the body is buffered to allow resending it after an auth challenge
+	var body bytes.Buffer
+	m := multipart.NewWriter(&body)
+
+	fields := [][2]string{
+		{"buildResultItemId", b.ItemId},
+		{"contributionType", contributionType},
+		{"label", name},
+	}
+	for _, field := range fields {
+		if err := m.WriteField(field[0], field[1]); err != nil {
+			return fmt.Errorf("failed to upload %s: %w", name, err)
+		}
+	}
+
+	part, err := m.CreateFormFile("file", name)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", name, err)
+	}
+	if _, err := io.Copy(part, fileReader); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", name, err)
+	}
+	if err := m.Close(); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", name, err)
+	}
+
+	response, err := b.ccm.client.post(ctx, ccmBuildService+"postAddFileContribution", m.FormDataContentType(), &body)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", name, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		return errorFromResponse(fmt.Sprintf("failed to upload %s", name), response, nil)
+	}
+	return nil
+}
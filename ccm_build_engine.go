@@ -0,0 +1,254 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// CCMBuildRequest is a request to execute a build of a build definition
+type CCMBuildRequest struct {
+	// ItemId of the build request
+	ItemId string
+
+	// BuildDefinitionId contains the item ID of the requested build definition
+	BuildDefinitionId string
+
+	// BuildResultId contains the item ID of the build result created for this
+	// request (only set after the request was claimed)
+	BuildResultId string
+
+	// Properties of the build (defaults of the build definition with overrides)
+	Properties map[string]string
+
+	// ccm Application instance used for interactions with the server
+	ccm *CCMApplication
+}
+
+// BuildDefinition of this request
+func (r *CCMBuildRequest) BuildDefinition(ctx context.Context) (*CCMBuildDefinition, error) {
+	return CCMGet[*CCMBuildDefinition](ctx, r.ccm, r.BuildDefinitionId)
+}
+
+// buildRequestValue is the representation of a build request in service responses
+type buildRequestValue struct {
+	ItemId          string `json:"itemId"`
+	BuildDefinition struct {
+		ItemId string `json:"itemId"`
+	} `json:"buildDefinition"`
+	BuildResult struct {
+		ItemId string `json:"itemId"`
+	} `json:"buildResult"`
+	Properties []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"properties"`
+}
+
+// request converts the service value to a CCMBuildRequest
+func (v *buildRequestValue) request(ccm *CCMApplication) *CCMBuildRequest {
+	request := &CCMBuildRequest{
+		ItemId:            v.ItemId,
+		BuildDefinitionId: v.BuildDefinition.ItemId,
+		BuildResultId:     v.BuildResult.ItemId,
+		Properties:        make(map[string]string, len(v.Properties)),
+		ccm:               ccm,
+	}
+	for _, property := range v.Properties {
+		request.Properties[property.Name] = property.Value
+	}
+	return request
+}
+
+// RequestBuild of the given build definition with the given property overrides
+func (a *CCMApplication) RequestBuild(ctx context.Context, definition *CCMBuildDefinition, properties map[string]string, personal bool) (*CCMBuildRequest, error) {
+	values := url.Values{
+		"buildDefinitionItemId": {definition.ItemId},
+		"personalBuild":         {strconv.FormatBool(personal)},
+	}
+
+	// add properties in a stable order
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values.Add("propertyName", name)
+		values.Add("propertyValue", properties[name])
+	}
+
+	var value buildRequestValue
+	err := a.servicePost(ctx, ccmBuildService+"postRequestBuild", values, &value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request build of %s: %w", definition.Id, err)
+	}
+	return value.request(a), nil
+}
+
+// StartActivity adds an activity to the build result (shown as build progress)
+func (b *CCMBuild) StartActivity(ctx context.Context, label string) error {
+	return b.update(ctx, "postStartActivity", url.Values{
+		"label": {label},
+	})
+}
+
+// CCMBuildHandler executes the build of a claimed build request. The build
+// result is completed with status ERROR if an error is returned, otherwise
+// with status OK (if not already completed by the handler).
+type CCMBuildHandler func(ctx context.Context, request *CCMBuildRequest, build *CCMBuild) error
+
+// default interval between polls of a CCMBuildEngineLoop
+const ccmBuildEngineInterval = 30 * time.Second
+
+// CCMBuildEngineLoop acts as a build engine and executes build requests
+// assigned to it
+type CCMBuildEngineLoop struct {
+	// Engine to act as
+	Engine *CCMBuildEngine
+
+	// Handler executed for each claimed build request
+	Handler CCMBuildHandler
+
+	// Interval between polls for pending build requests (30 seconds if not
+	// set)
+	Interval time.Duration
+}
+
+// NewCCMBuildEngineLoop creates a new build engine loop for the given engine
+func NewCCMBuildEngineLoop(engine *CCMBuildEngine, handler CCMBuildHandler) *CCMBuildEngineLoop {
+	return &CCMBuildEngineLoop{
+		Engine:   engine,
+		Handler:  handler,
+		Interval: ccmBuildEngineInterval,
+	}
+}
+
+// Run polls for pending build requests until the context is canceled
+func (l *CCMBuildEngineLoop) Run(ctx context.Context) error {
+	if l.Engine.ccm == nil {
+		return errors.New("build engine is not bound to a CCM application")
+	}
+
+	interval := l.Interval
+	if interval <= 0 {
+		interval = ccmBuildEngineInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := l.Poll(ctx)
+		if err != nil {
+			l.Engine.ccm.client.Logger.Sugar().Errorf("build engine %s: %s", l.Engine.Id, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll once for pending build requests and execute them
+func (l *CCMBuildEngineLoop) Poll(ctx context.Context) error {
+	ccm := l.Engine.ccm
+
+	// tell the server the engine is alive
+	err := ccm.servicePost(ctx, ccmBuildService+"postEngineHeartbeat", url.Values{
+		"engineItemId": {l.Engine.ItemId},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send heartbeat: %w", err)
+	}
+
+	var pending []buildRequestValue
+	err = ccm.serviceGet(ctx,
+		ccmBuildService+"pendingRequests?engineItemId="+url.QueryEscape(l.Engine.ItemId),
+		&pending)
+	if err != nil {
+		return fmt.Errorf("failed to get pending build requests: %w", err)
+	}
+
+	for _, value := range pending {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		err = l.execute(ctx, value.request(ccm))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execute claims the given request and runs the handler
+func (l *CCMBuildEngineLoop) execute(ctx context.Context, request *CCMBuildRequest) error {
+	ccm := l.Engine.ccm
+
+	// claim request (fails if it was claimed by another engine)
+	var claimed buildRequestValue
+	err := ccm.servicePost(ctx, ccmBuildService+"postClaimRequest", url.Values{
+		"requestItemId": {request.ItemId},
+		"engineItemId":  {l.Engine.ItemId},
+	}, &claimed)
+	if err != nil {
+		return fmt.Errorf("failed to claim request %s: %w", request.ItemId, err)
+	}
+	request = claimed.request(ccm)
+	if request.BuildResultId == "" {
+		return fmt.Errorf("failed to claim request %s: no build result returned", request.ItemId)
+	}
+
+	build := &CCMBuild{
+		ItemId: request.BuildResultId,
+		ccm:    ccm,
+	}
+
+	// run build and report result
+	handlerErr := l.runHandler(ctx, request, build)
+	result, err := build.Result(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get build result of request %s: %w", request.ItemId, err)
+	}
+	if result.BuildState == CCMBuildStateCompleted {
+		return nil
+	}
+
+	status := CCMBuildStatusOk
+	if handlerErr != nil {
+		ccm.client.Logger.Sugar().Errorf("build engine %s: build %s failed: %s",
+			l.Engine.Id, result.Label, handlerErr)
+		status = CCMBuildStatusError
+	}
+	return build.Complete(ctx, status)
+}
+
+// runHandler executes the handler and returns panics of it as error
+func (l *CCMBuildEngineLoop) runHandler(ctx context.Context, request *CCMBuildRequest, build *CCMBuild) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("build handler panic: %v", r)
+		}
+	}()
+	return l.Handler(ctx, request, build)
+}
@@ -0,0 +1,204 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ccmTestBuildService is a fake build service with one pending request
+type ccmTestBuildService struct {
+	// claim is the response of postClaimRequest (error status if empty)
+	claim string
+
+	// completed is called after a build result was completed (if set)
+	completed func()
+
+	mutex    sync.Mutex
+	requests []string
+}
+
+// handle requests of the build service
+func (s *ccmTestBuildService) handle(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var value string
+	switch r.URL.Path {
+	case "/" + ccmBuildService + "postEngineHeartbeat":
+		s.requests = append(s.requests, "heartbeat:"+r.FormValue("engineItemId"))
+		return
+
+	case "/" + ccmBuildService + "pendingRequests":
+		value = `{"values":[{"itemId":"_r1","buildDefinition":{"itemId":"_bd1"}}]}`
+
+	case "/" + ccmBuildService + "postClaimRequest":
+		s.requests = append(s.requests, "claim:"+r.FormValue("requestItemId"))
+		if s.claim == "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		value = `{"value":` + s.claim + `}`
+
+	case "/" + ccmBuildService + "postUpdateBuildResult":
+		s.requests = append(s.requests, fmt.Sprintf("complete:%s:%s",
+			r.FormValue("buildResultItemId"), r.FormValue("buildStatus")))
+		if s.completed != nil {
+			s.completed()
+		}
+		return
+
+	case "/ccm/rpt/repository/build":
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = fmt.Fprint(w, `<build><buildResult><itemId>_br1</itemId><stateId>_s1</stateId>
+<label>build 1</label><buildState>IN_PROGRESS</buildState></buildResult></build>`)
+		return
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/json")
+	_, _ = fmt.Fprintf(w, `{"soapenv:Body":{"response":{"returnValue":%s}}}`, value)
+}
+
+// loop returns a build engine loop for this service with the given handler
+func (s *ccmTestBuildService) loop(t *testing.T, handler CCMBuildHandler) *CCMBuildEngineLoop {
+	server := httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL+"/", "user", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	engine := &CCMBuildEngine{Id: "engine"}
+	engine.ItemId = "_e1"
+	engine.setCCM(client.CCM)
+	return NewCCMBuildEngineLoop(engine, handler)
+}
+
+func TestCCMBuildEngineLoopPoll(t *testing.T) {
+	const claim = `{"itemId":"_r1","buildDefinition":{"itemId":"_bd1"},"buildResult":{"itemId":"_br1"},
+"properties":[{"name":"target","value":"release"}]}`
+
+	tests := []struct {
+		name     string
+		claim    string
+		handler  CCMBuildHandler
+		requests string
+		err      string
+	}{
+		{"ok", claim, func(context.Context, *CCMBuildRequest, *CCMBuild) error {
+			return nil
+		}, "heartbeat:_e1,claim:_r1,complete:_br1:OK", ""},
+		{"handler error", claim, func(context.Context, *CCMBuildRequest, *CCMBuild) error {
+			return errors.New("failed")
+		}, "heartbeat:_e1,claim:_r1,complete:_br1:ERROR", ""},
+		{"handler panic", claim, func(context.Context, *CCMBuildRequest, *CCMBuild) error {
+			panic("failed")
+		}, "heartbeat:_e1,claim:_r1,complete:_br1:ERROR", ""},
+		{"claim failed", "", nil,
+			"heartbeat:_e1,claim:_r1", "failed to claim request _r1: service request failed: 500"},
+		{"no build result", `{"itemId":"_r1"}`, nil,
+			"heartbeat:_e1,claim:_r1", "failed to claim request _r1: no build result returned"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := &ccmTestBuildService{claim: test.claim}
+
+			var handled []string
+			loop := service.loop(t, func(ctx context.Context, request *CCMBuildRequest, build *CCMBuild) error {
+				handled = append(handled, fmt.Sprintf("%s:%s:%s:%s",
+					request.ItemId, request.BuildDefinitionId, build.ItemId, request.Properties["target"]))
+				return test.handler(ctx, request, build)
+			})
+
+			err := loop.Poll(context.Background())
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if len(handled) != 0 {
+					t.Errorf("handler called for unclaimed request: %v", handled)
+				}
+			} else {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if strings.Join(handled, ",") != "_r1:_bd1:_br1:release" {
+					t.Errorf("unexpected handled requests: %v", handled)
+				}
+			}
+
+			if strings.Join(service.requests, ",") != test.requests {
+				t.Errorf("unexpected requests: %v", service.requests)
+			}
+		})
+	}
+}
+
+func TestCCMBuildEngineLoopRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// stop the loop after the first build
+	service := &ccmTestBuildService{
+		claim:     `{"itemId":"_r1","buildResult":{"itemId":"_br1"}}`,
+		completed: cancel,
+	}
+	loop := service.loop(t, func(context.Context, *CCMBuildRequest, *CCMBuild) error {
+		panic("failed")
+	})
+	// invalid interval is replaced by default
+	loop.Interval = 0
+
+	done := make(chan error, 1)
+	go func() {
+		done <- loop.Run(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("build engine loop not stopped")
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	if strings.Join(service.requests, ",") != "heartbeat:_e1,claim:_r1,complete:_br1:ERROR" {
+		t.Errorf("unexpected requests: %v", service.requests)
+	}
+}
+
+func TestCCMBuildEngineLoopUnbound(t *testing.T) {
+	loop := NewCCMBuildEngineLoop(&CCMBuildEngine{}, nil)
+	err := loop.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "not bound") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Events of a CCMUnitTestEvent
+const (
+	CCMUnitTestEventNewFailure   = "NEW_FAILURE"
+	CCMUnitTestEventNewError     = "NEW_ERROR"
+	CCMUnitTestEventRegression   = "REGRESSION"
+	CCMUnitTestEventFixed        = "FIXED"
+	CCMUnitTestEventFailureFixed = "FAILURE_FIXED"
+	CCMUnitTestEventErrorFixed   = "ERROR_FIXED"
+)
+
+// CCMUnitTestEventFailing maps the events of a CCMUnitTestEvent to the status
+// of the test after the event (true = failing). The literals are not part of
+// the reportable REST API documentation, events not in this map are ignored.
+var CCMUnitTestEventFailing = map[string]bool{
+	CCMUnitTestEventNewFailure:   true,
+	CCMUnitTestEventNewError:     true,
+	CCMUnitTestEventRegression:   true,
+	CCMUnitTestEventFixed:        false,
+	CCMUnitTestEventFailureFixed: false,
+	CCMUnitTestEventErrorFixed:   false,
+}
+
+// CCMBuildHealthPoint contains the test results of a single build
+type CCMBuildHealthPoint struct {
+	// Label of the build
+	Label string `json:"label"`
+
+	// StartTime of the build
+	StartTime *time.Time `json:"startTime,omitempty"`
+
+	// Status of the build (see CCMBuildStatus*)
+	Status string `json:"status"`
+
+	// Duration of the build
+	Duration CCMDuration `json:"duration"`
+
+	// Tests is the number of executed tests
+	Tests int64 `json:"tests"`
+
+	// Failed is the number of failed tests (failures and errors)
+	Failed int64 `json:"failed"`
+
+	// PassRate of the tests (0-1, 1 if no tests were executed)
+	PassRate float64 `json:"passRate"`
+}
+
+// CCMTestHealth contains the status history of a single test
+type CCMTestHealth struct {
+	// Component of the test
+	Component string `json:"component"`
+
+	// Test name
+	Test string `json:"test"`
+
+	// Failing is true if the test fails in the latest build
+	Failing bool `json:"failing"`
+
+	// Flips is the number of status changes between consecutive builds
+	Flips int `json:"flips"`
+
+	// Flaky is true if the status of the test changed at least twice (e.g.
+	// failing, passing and failing again)
+	Flaky bool `json:"flaky"`
+
+	// Regression is true if the test passed before and fails since a single
+	// status change
+	Regression bool `json:"regression"`
+
+	// FirstFailingBuild contains the label of the first build the test
+	// failed in (empty if it never failed)
+	FirstFailingBuild string `json:"firstFailingBuild,omitempty"`
+
+	// FirstFailure contains the start time of FirstFailingBuild
+	FirstFailure *time.Time `json:"firstFailure,omitempty"`
+}
+
+// CCMBuildHealth contains the build health of a build definition
+type CCMBuildHealth struct {
+	// Definition is the ID of the build definition
+	Definition string `json:"definition"`
+
+	// Builds sorted by start time
+	Builds []*CCMBuildHealthPoint `json:"builds"`
+
+	// Tests with status changes sorted by component and test name
+	Tests []*CCMTestHealth `json:"tests"`
+
+	// PassRate of all tests in all builds
+	PassRate float64 `json:"passRate"`
+
+	// MeanDuration of all builds
+	MeanDuration CCMDuration `json:"meanDuration"`
+}
+
+// CCMAnalyzeBuilds computes the build health of the given build definition
+// from all completed non personal build results
+func CCMAnalyzeBuilds(ctx context.Context, ccm *CCMApplication, definition *CCMBuildDefinition) (*CCMBuildHealth, error) {
+	results, err := CCMList[*CCMBuildResult](ctx, ccm, CCMFilter{
+		"BuildDefinition": {definition.ItemId},
+		"BuildState":      {CCMBuildStateCompleted},
+		"PersonalBuild":   {false},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get build results of %s: %w", definition.Id, err)
+	}
+	return CCMAnalyzeBuildResults(definition.Id, results), nil
+}
+
+// CCMAnalyzeBuildResults computes the build health of the given build results.
+// The status of tests is tracked through the unit test events of the builds.
+func CCMAnalyzeBuildResults(definition string, results []*CCMBuildResult) *CCMBuildHealth {
+	health := &CCMBuildHealth{
+		Definition: definition,
+		Builds:     make([]*CCMBuildHealthPoint, 0, len(results)),
+	}
+
+	// analyze builds in chronological order
+	results = append([]*CCMBuildResult(nil), results...)
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i].StartTime, results[j].StartTime
+		if a == nil || b == nil {
+			return b != nil
+		}
+		return a.Before(*b)
+	})
+
+	var totalTests, totalFailed int64
+	var totalDuration time.Duration
+	tests := make(map[string]*CCMTestHealth)
+	for i, result := range results {
+		point := &CCMBuildHealthPoint{
+			Label:     result.Label,
+			StartTime: result.StartTime,
+			Status:    result.BuildStatus,
+			Duration:  CCMDuration(time.Duration(result.TimeTaken) * time.Millisecond),
+			PassRate:  1,
+		}
+		for _, testResult := range result.UnitTestResults {
+			point.Tests += testResult.Tests
+			point.Failed += testResult.Failures + testResult.Errors
+		}
+		if point.Tests > 0 {
+			point.PassRate = float64(point.Tests-point.Failed) / float64(point.Tests)
+		}
+		health.Builds = append(health.Builds, point)
+
+		totalTests += point.Tests
+		totalFailed += point.Failed
+		totalDuration += time.Duration(point.Duration)
+
+		// track status changes of tests (every event is a change to the
+		// previous build, which is unknown for the first build)
+		for _, event := range result.UnitTestEvents {
+			failing, ok := CCMUnitTestEventFailing[event.Event]
+			if !ok {
+				continue
+			}
+
+			key := event.Component + "/" + event.Test
+			test, seen := tests[key]
+			if !seen {
+				test = &CCMTestHealth{
+					Component: event.Component,
+					Test:      event.Test,
+				}
+				tests[key] = test
+			} else if failing == test.Failing {
+				continue
+			}
+
+			if seen || i > 0 {
+				test.Flips++
+			}
+			test.Failing = failing
+			test.Flaky = test.Flips >= 2
+			test.Regression = failing && test.Flips == 1
+
+			if failing && test.FirstFailingBuild == "" {
+				test.FirstFailingBuild = result.Label
+				test.FirstFailure = result.StartTime
+			}
+		}
+	}
+
+	if totalTests > 0 {
+		health.PassRate = float64(totalTests-totalFailed) / float64(totalTests)
+	} else {
+		health.PassRate = 1
+	}
+	if len(results) > 0 {
+		health.MeanDuration = CCMDuration(totalDuration / time.Duration(len(results)))
+	}
+
+	health.Tests = make([]*CCMTestHealth, 0, len(tests))
+	for _, test := range tests {
+		health.Tests = append(health.Tests, test)
+	}
+	sort.Slice(health.Tests, func(i, j int) bool {
+		a, b := health.Tests[i], health.Tests[j]
+		if a.Component != b.Component {
+			return a.Component < b.Component
+		}
+		return a.Test < b.Test
+	})
+	return health
+}
+
+// FlakyTests returns all tests that changed their status at least twice
+func (h *CCMBuildHealth) FlakyTests() []*CCMTestHealth {
+	var tests []*CCMTestHealth
+	for _, test := range h.Tests {
+		if test.Flaky {
+			tests = append(tests, test)
+		}
+	}
+	return tests
+}
+
+// RegressedTests returns all tests that passed before and fail since a
+// single status change
+func (h *CCMBuildHealth) RegressedTests() []*CCMTestHealth {
+	var tests []*CCMTestHealth
+	for _, test := range h.Tests {
+		if test.Regression {
+			tests = append(tests, test)
+		}
+	}
+	return tests
+}
+
+// WriteJSON writes the build health as JSON
+func (h *CCMBuildHealth) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(h)
+}
+
+// WriteBuildsCSV writes the health of each build as CSV
+// (durations are written in hours)
+func (h *CCMBuildHealth) WriteBuildsCSV(w io.Writer) error {
+	rows := make([][]string, len(h.Builds))
+	for i, build := range h.Builds {
+		rows[i] = []string{
+			build.Label,
+			formatCSVTime(build.StartTime),
+			build.Status,
+			formatCSVHours(build.Duration),
+			strconv.FormatInt(build.Tests, 10),
+			strconv.FormatInt(build.Failed, 10),
+			strconv.FormatFloat(build.PassRate, 'f', 4, 64),
+		}
+	}
+	return writeCSV(w,
+		[]string{"label", "start time", "status", "duration", "tests", "failed", "pass rate"},
+		rows)
+}
+
+// WriteTestsCSV writes the status history of all tests as CSV
+func (h *CCMBuildHealth) WriteTestsCSV(w io.Writer) error {
+	rows := make([][]string, len(h.Tests))
+	for i, test := range h.Tests {
+		rows[i] = []string{
+			test.Component,
+			test.Test,
+			strconv.FormatBool(test.Failing),
+			strconv.Itoa(test.Flips),
+			strconv.FormatBool(test.Flaky),
+			strconv.FormatBool(test.Regression),
+			test.FirstFailingBuild,
+			formatCSVTime(test.FirstFailure),
+		}
+	}
+	return writeCSV(w,
+		[]string{"component", "test", "failing", "flips", "flaky", "regression", "first failing build", "first failure"},
+		rows)
+}
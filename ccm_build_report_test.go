@@ -0,0 +1,227 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// ccmTestBuild creates a build result started at the given hour with the
+// given unit test events (test name -> event)
+func ccmTestBuild(label string, hour int, events map[string]string) *CCMBuildResult {
+	start := time.Date(2022, 3, 1, hour, 0, 0, 0, time.UTC)
+	result := &CCMBuildResult{
+		Label:       label,
+		StartTime:   &start,
+		BuildStatus: CCMBuildStatusOk,
+		TimeTaken:   int64(time.Hour / time.Millisecond),
+	}
+	for test, event := range events {
+		result.UnitTestEvents = append(result.UnitTestEvents, &CCMUnitTestEvent{
+			Component: "core",
+			Test:      test,
+			Event:     event,
+		})
+	}
+	return result
+}
+
+func TestCCMAnalyzeBuildResultsTests(t *testing.T) {
+	tests := []struct {
+		name   string
+		builds []map[string]string
+
+		failing           bool
+		flips             int
+		flaky             bool
+		regression        bool
+		firstFailingBuild string
+	}{
+		{
+			name: "failing since first build",
+			builds: []map[string]string{
+				{"test": CCMUnitTestEventNewFailure},
+				{},
+			},
+			failing:           true,
+			firstFailingBuild: "b1",
+		},
+		{
+			name: "pass to fail",
+			builds: []map[string]string{
+				{},
+				{"test": CCMUnitTestEventNewFailure},
+			},
+			failing:           true,
+			flips:             1,
+			regression:        true,
+			firstFailingBuild: "b2",
+		},
+		{
+			name: "fail to pass",
+			builds: []map[string]string{
+				{},
+				{"test": CCMUnitTestEventFixed},
+			},
+			flips: 1,
+		},
+		{
+			name: "fail pass fail",
+			builds: []map[string]string{
+				{"test": CCMUnitTestEventNewError},
+				{"test": CCMUnitTestEventFailureFixed},
+				{"test": CCMUnitTestEventRegression},
+			},
+			failing:           true,
+			flips:             2,
+			flaky:             true,
+			firstFailingBuild: "b1",
+		},
+		{
+			name: "pass fail pass",
+			builds: []map[string]string{
+				{},
+				{"test": CCMUnitTestEventNewFailure},
+				{"test": CCMUnitTestEventFixed},
+			},
+			flips:             2,
+			flaky:             true,
+			firstFailingBuild: "b2",
+		},
+		{
+			name: "pass fail pass fail",
+			builds: []map[string]string{
+				{},
+				{"test": CCMUnitTestEventNewFailure},
+				{"test": CCMUnitTestEventFixed},
+				{"test": CCMUnitTestEventRegression},
+			},
+			failing:           true,
+			flips:             3,
+			flaky:             true,
+			firstFailingBuild: "b2",
+		},
+		{
+			name: "repeated event is no change",
+			builds: []map[string]string{
+				{"test": CCMUnitTestEventNewFailure},
+				{"test": CCMUnitTestEventRegression},
+			},
+			failing:           true,
+			firstFailingBuild: "b1",
+		},
+		{
+			name: "fixed is not a failure",
+			builds: []map[string]string{
+				{"test": CCMUnitTestEventFailureFixed},
+				{"test": CCMUnitTestEventErrorFixed},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := make([]*CCMBuildResult, len(test.builds))
+			for i, events := range test.builds {
+				results[i] = ccmTestBuild("b"+string(rune('1'+i)), i, events)
+			}
+
+			// order of results must not matter
+			results[0], results[len(results)-1] = results[len(results)-1], results[0]
+
+			health := CCMAnalyzeBuildResults("def", results)
+			if len(health.Tests) != 1 {
+				t.Fatalf("expected 1 test, got %d", len(health.Tests))
+			}
+			testHealth := health.Tests[0]
+			if testHealth.Failing != test.failing {
+				t.Errorf("got failing %t, expected %t", testHealth.Failing, test.failing)
+			}
+			if testHealth.Flips != test.flips {
+				t.Errorf("got %d flips, expected %d", testHealth.Flips, test.flips)
+			}
+			if testHealth.Flaky != test.flaky {
+				t.Errorf("got flaky %t, expected %t", testHealth.Flaky, test.flaky)
+			}
+			if testHealth.FirstFailingBuild != test.firstFailingBuild {
+				t.Errorf("got first failing build \"%s\", expected \"%s\"",
+					testHealth.FirstFailingBuild, test.firstFailingBuild)
+			}
+			if flaky := len(health.FlakyTests()) > 0; flaky != test.flaky {
+				t.Errorf("got flaky tests %t, expected %t", flaky, test.flaky)
+			}
+			if testHealth.Regression != test.regression {
+				t.Errorf("got regression %t, expected %t", testHealth.Regression, test.regression)
+			}
+			if regression := len(health.RegressedTests()) > 0; regression != test.regression {
+				t.Errorf("got regressed tests %t, expected %t", regression, test.regression)
+			}
+		})
+	}
+}
+
+func TestCCMAnalyzeBuildResultsUnknownEvent(t *testing.T) {
+	health := CCMAnalyzeBuildResults("def", []*CCMBuildResult{
+		ccmTestBuild("b1", 0, nil),
+		ccmTestBuild("b2", 1, map[string]string{"test": "SOMETHING_ELSE"}),
+	})
+	if len(health.Tests) != 0 {
+		t.Errorf("expected unknown event to be ignored, got %+v", health.Tests[0])
+	}
+}
+
+func TestCCMAnalyzeBuildResultsBuilds(t *testing.T) {
+	first := ccmTestBuild("b1", 0, nil)
+	first.UnitTestResults = []*CCMUnitTestResult{
+		{Component: "core", Tests: 10, Failures: 1, Errors: 1},
+		{Component: "ui", Tests: 10},
+	}
+	second := ccmTestBuild("b2", 2, nil)
+	second.TimeTaken = int64(3 * time.Hour / time.Millisecond)
+
+	health := CCMAnalyzeBuildResults("def", []*CCMBuildResult{second, first})
+	if health.Definition != "def" {
+		t.Errorf("got definition %s", health.Definition)
+	}
+	if len(health.Builds) != 2 || health.Builds[0].Label != "b1" || health.Builds[1].Label != "b2" {
+		t.Fatalf("builds not sorted by start time: %+v", health.Builds)
+	}
+	if health.Builds[0].Tests != 20 || health.Builds[0].Failed != 2 || health.Builds[0].PassRate != 0.9 {
+		t.Errorf("unexpected test results of first build: %+v", health.Builds[0])
+	}
+	if health.Builds[1].PassRate != 1 {
+		t.Errorf("expected pass rate 1 for build without tests, got %f", health.Builds[1].PassRate)
+	}
+	if health.PassRate != 0.9 {
+		t.Errorf("got pass rate %f, expected 0.9", health.PassRate)
+	}
+	if time.Duration(health.MeanDuration) != 2*time.Hour {
+		t.Errorf("got mean duration %s, expected 2h", health.MeanDuration)
+	}
+
+	var buffer bytes.Buffer
+	err := health.WriteBuildsCSV(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "label,start time,status,duration,tests,failed,pass rate\n" +
+		"b1,2022-03-01T00:00:00Z,OK,1.00,20,2,0.9000\n" +
+		"b2,2022-03-01T02:00:00Z,OK,3.00,0,0,1.0000\n"
+	if buffer.String() != expected {
+		t.Errorf("got CSV:\n%s\nexpected:\n%s", buffer.String(), expected)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCCMBuildAddLogAuth(t *testing.T) {
+	tests := []struct {
+		name string
+		auth string
+	}{
+		{"no auth", ""},
+		{"basic auth", "basic"},
+		{"form challenge", "form"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var mutex sync.Mutex
+			var uploads []string
+			var loggedIn bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				if r.URL.Path == "/jts/j_security_check" {
+					loggedIn = true
+					return
+				}
+				if r.URL.Path != "/"+ccmBuildService+"postAddFileContribution" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				// the first request of the upload triggers the auth challenge
+				_, _, basicAuth := r.BasicAuth()
+				switch {
+				case test.auth == "basic" && !basicAuth:
+					w.Header().Set("www-authenticate", "Basic realm=\"jazz\"")
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				case test.auth == "form" && !loggedIn:
+					w.Header().Set("x-com-ibm-team-repository-web-auth-msg", "authrequired")
+					return
+				}
+
+				file, _, err := r.FormFile("file")
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				content, _ := io.ReadAll(file)
+				uploads = append(uploads, strings.Join([]string{
+					r.FormValue("buildResultItemId"),
+					r.FormValue("contributionType"),
+					r.FormValue("label"),
+					string(content),
+				}, ":"))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.URL+"/", "user", "password")
+			if err != nil {
+				t.Fatal(err)
+			}
+			build := &CCMBuild{ItemId: "_br1", ccm: client.CCM}
+
+			err = build.AddLog(context.Background(), "build.log", strings.NewReader("log content"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := "_br1:com.ibm.team.build.common.model.IBuildResultContribution.log:build.log:log content"
+			if len(uploads) != 1 || uploads[0] != expected {
+				t.Errorf("unexpected uploads: %v", uploads)
+			}
+		})
+	}
+}
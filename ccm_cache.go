@@ -0,0 +1,288 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"container/list"
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/beevik/etree"
+	"golang.org/x/sync/singleflight"
+)
+
+// CCMCache caches the responses of loaded CCM objects by item ID.
+// Concurrent loads of the same object result in a single request.
+//
+// Objects referenced by loaded objects (e.g. the owner of a work item) are
+// shared: every reference to the same item ID points to the same instance,
+// so the referenced object is only loaded once.
+type CCMCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	items   map[string]map[string]*list.Element // entries by item ID
+	lru     *list.List
+	group   singleflight.Group
+}
+
+// ccmCacheEntry is a single object in the CCMCache
+type ccmCacheEntry struct {
+	key     string
+	itemId  string
+	stateId string
+	element *etree.Element // nil if only referenced
+	object  reflect.Value  // shared instance of references (may be invalid)
+	expires time.Time
+}
+
+// NewCCMCache creates a new cache. Entries expire after the given TTL
+// (0 = never) and the least recently used objects are removed if more
+// than maxEntries (0 = unlimited) are loaded. Shared instances of referenced
+// objects are not limited by maxEntries and are kept until they expire or
+// are invalidated.
+func NewCCMCache(ttl time.Duration, maxEntries int) *CCMCache {
+	return &CCMCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		items:      make(map[string]map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// ccmCacheKey of the object with the given ID
+func ccmCacheKey(spec *CCMObjectSpec, itemId string) string {
+	return spec.Type.String() + "/" + itemId
+}
+
+// get the element of the object with the given ID from the cache or load
+// it. The load is shared by concurrent callers and is not canceled if only
+// some of them are canceled.
+func (c *CCMCache) get(ctx context.Context, spec *CCMObjectSpec, itemId string,
+	load func(ctx context.Context) (*etree.Element, error)) (*etree.Element, error) {
+
+	key := ccmCacheKey(spec, itemId)
+	if element, ok := c.lookup(key); ok {
+		return element, nil
+	}
+
+	loadCtx := context.WithoutCancel(ctx)
+	results := c.group.DoChan(key, func() (interface{}, error) {
+		// may be loaded while waiting
+		if element, ok := c.lookup(key); ok {
+			return element, nil
+		}
+
+		element, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+		c.store(key, itemId, element)
+		return element, nil
+	})
+
+	select {
+	case result := <-results:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.(*etree.Element), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// lookup a valid entry in the cache
+func (c *CCMCache) lookup(key string) (*etree.Element, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	item, ok := c.validEntry(key)
+	if !ok {
+		return nil, false
+	}
+
+	entry := item.Value.(*ccmCacheEntry)
+	if entry.element == nil {
+		return nil, false
+	}
+	c.lru.MoveToFront(item)
+	return entry.element, true
+}
+
+// validEntry returns the entry with the given key if not expired (mutex
+// must be locked)
+func (c *CCMCache) validEntry(key string) (*list.Element, bool) {
+	item, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := item.Value.(*ccmCacheEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.remove(item)
+		return nil, false
+	}
+	return item, true
+}
+
+// reference returns the shared instance of the referenced object with the
+// given ID. If no instance exists the given one is stored and returned.
+func (c *CCMCache) reference(spec *CCMObjectSpec, itemId string, object reflect.Value) reflect.Value {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := ccmCacheKey(spec, itemId)
+	item, ok := c.validEntry(key)
+	if ok {
+		entry := item.Value.(*ccmCacheEntry)
+		if !entry.object.IsValid() {
+			entry.object = object
+		}
+		c.lru.MoveToFront(item)
+		return entry.object
+	}
+
+	c.add(&ccmCacheEntry{
+		key:    key,
+		itemId: itemId,
+		object: object,
+	})
+	return object
+}
+
+// store an element in the cache
+func (c *CCMCache) store(key, itemId string, element *etree.Element) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry := &ccmCacheEntry{
+		key:     key,
+		itemId:  itemId,
+		element: element,
+	}
+	if stateId := element.SelectElement("stateId"); stateId != nil {
+		entry.stateId = stateId.Text()
+	}
+
+	// keep the shared instance of references
+	if item, ok := c.entries[key]; ok {
+		entry.object = item.Value.(*ccmCacheEntry).object
+	}
+	c.add(entry)
+}
+
+// add an entry to the cache or replace the existing one (mutex must be
+// locked)
+func (c *CCMCache) add(entry *ccmCacheEntry) {
+	if c.ttl > 0 {
+		entry.expires = time.Now().Add(c.ttl)
+	}
+
+	if item, ok := c.entries[entry.key]; ok {
+		c.remove(item)
+	}
+
+	// only loaded objects are part of the LRU list, removing references
+	// would break the shared instances
+	var item *list.Element
+	if entry.element != nil {
+		item = c.lru.PushFront(entry)
+	} else {
+		item = &list.Element{Value: entry}
+	}
+	c.entries[entry.key] = item
+	if c.items[entry.itemId] == nil {
+		c.items[entry.itemId] = make(map[string]*list.Element)
+	}
+	c.items[entry.itemId][entry.key] = item
+
+	// remove least recently used objects
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		c.evict(c.lru.Back())
+	}
+}
+
+// evict removes the loaded object of the entry but keeps the shared instance
+// of references (mutex must be locked)
+func (c *CCMCache) evict(item *list.Element) {
+	entry := item.Value.(*ccmCacheEntry)
+	if !entry.object.IsValid() {
+		c.remove(item)
+		return
+	}
+
+	c.lru.Remove(item)
+	entry.element = nil
+}
+
+// remove entry from cache (mutex must be locked)
+func (c *CCMCache) remove(item *list.Element) {
+	entry := item.Value.(*ccmCacheEntry)
+	c.lru.Remove(item)
+	delete(c.entries, entry.key)
+	delete(c.items[entry.itemId], entry.key)
+	if len(c.items[entry.itemId]) == 0 {
+		delete(c.items, entry.itemId)
+	}
+}
+
+// Validate removes all entries of the item with the given ID if their state
+// is not the given one
+func (c *CCMCache) Validate(itemId, stateId string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, item := range c.items[itemId] {
+		// references that were never loaded have no state
+		entryState := item.Value.(*ccmCacheEntry).stateId
+		if entryState != "" && entryState != stateId {
+			c.remove(item)
+		}
+	}
+}
+
+// Invalidate removes all entries of the item with the given ID
+func (c *CCMCache) Invalidate(itemId string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, item := range c.items[itemId] {
+		c.remove(item)
+	}
+}
+
+// Clear removes all entries from the cache
+func (c *CCMCache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.items = make(map[string]map[string]*list.Element)
+	c.lru.Init()
+}
+
+// Len returns the number of entries in the cache (loaded objects and shared
+// references)
+func (c *CCMCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.entries)
+}
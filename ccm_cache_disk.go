@@ -0,0 +1,126 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/beevik/etree"
+)
+
+// CCMDiskCache persists loaded CCM objects in a directory. Every object is
+// stored by its item ID and state ID, so a stored object is only used as
+// long as the object was not changed on the server.
+type CCMDiskCache struct {
+	dir string
+}
+
+// NewCCMDiskCache creates a disk cache in the given directory
+func NewCCMDiskCache(dir string) (*CCMDiskCache, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &CCMDiskCache{dir: dir}, nil
+}
+
+// typeDir returns the directory of the given type. The directory contains a
+// hash of the loaded fields, so objects stored by other versions of the
+// models are not used.
+func (c *CCMDiskCache) typeDir(spec *CCMObjectSpec) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(strings.Join(spec.getLoadFields(spec.Type), "|")))
+	return filepath.Join(c.dir, fmt.Sprintf("%s-%08x", spec.Type.Name(), hash.Sum32()))
+}
+
+// path of the file for the given object state
+func (c *CCMDiskCache) path(spec *CCMObjectSpec, itemId, stateId string) string {
+	return filepath.Join(c.typeDir(spec), itemId+"_"+stateId+".xml")
+}
+
+// load the element of the given object state (nil if not stored)
+func (c *CCMDiskCache) load(spec *CCMObjectSpec, itemId, stateId string) (*etree.Element, error) {
+	doc := etree.NewDocument()
+	err := doc.ReadFromFile(c.path(spec, itemId, stateId))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc.Root(), nil
+}
+
+// store the element of an object and remove older states of the object
+func (c *CCMDiskCache) store(spec *CCMObjectSpec, itemId string, element *etree.Element) error {
+	stateId := element.SelectElement("stateId")
+	if stateId == nil {
+		return errors.New("object has no state ID")
+	}
+
+	dir := c.typeDir(spec)
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	// remove older states
+	oldFiles, err := filepath.Glob(filepath.Join(dir, itemId+"_*.xml"))
+	if err != nil {
+		return err
+	}
+	for _, file := range oldFiles {
+		_ = os.Remove(file)
+	}
+
+	// write to temporary file first to prevent partial files
+	doc := etree.NewDocument()
+	doc.SetRoot(element.Copy())
+	file, err := os.CreateTemp(dir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = doc.WriteTo(file)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+	return os.Rename(file.Name(), c.path(spec, itemId, stateId.Text()))
+}
+
+// Clear removes all objects from the cache
+func (c *CCMDiskCache) Clear() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		err = os.RemoveAll(filepath.Join(c.dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
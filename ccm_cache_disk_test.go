@@ -0,0 +1,185 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/beevik/etree"
+)
+
+func TestCCMDiskCacheStore(t *testing.T) {
+	cache, err := NewCCMDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := (&CCMContributor{}).Spec()
+
+	// not stored
+	element, err := cache.load(spec, "_a", "_s1")
+	if err != nil || element != nil {
+		t.Fatalf("expected no element, got %v (%v)", element, err)
+	}
+
+	err = cache.store(spec, "_a", ccmTestElement("_a", "_s1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	element, err = cache.load(spec, "_a", "_s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if element == nil || element.Tag != "contributor" || element.SelectElement("itemId").Text() != "_a" {
+		t.Fatalf("got wrong element %v", element)
+	}
+
+	// other states are not used
+	element, err = cache.load(spec, "_a", "_s2")
+	if err != nil || element != nil {
+		t.Errorf("expected no element for other state, got %v (%v)", element, err)
+	}
+}
+
+func TestCCMDiskCacheStaleStates(t *testing.T) {
+	cache, err := NewCCMDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := (&CCMContributor{}).Spec()
+
+	for _, element := range []*etree.Element{
+		ccmTestElement("_a", "_s1"),
+		ccmTestElement("_b", "_s1"),
+		ccmTestElement("_a", "_s2"),
+	} {
+		err = cache.store(spec, element.SelectElement("itemId").Text(), element)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(cache.typeDir(spec), "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, file := range files {
+		names = append(names, filepath.Base(file))
+	}
+	if strings.Join(names, ",") != "_a__s2.xml,_b__s1.xml" {
+		t.Errorf("expected only the current states, got %s", strings.Join(names, ","))
+	}
+}
+
+func TestCCMDiskCacheWithoutState(t *testing.T) {
+	cache, err := NewCCMDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	element := etree.NewElement("contributor")
+	element.CreateElement("itemId").SetText("_a")
+	err = cache.store((&CCMContributor{}).Spec(), "_a", element)
+	if err == nil {
+		t.Error("expected error for object without state ID")
+	}
+}
+
+func TestCCMDiskCacheTypeDir(t *testing.T) {
+	cache := &CCMDiskCache{dir: "cache"}
+
+	contributor := (&CCMContributor{}).Spec()
+	workItem := (&CCMWorkItem{}).Spec()
+	if cache.typeDir(contributor) == cache.typeDir(workItem) {
+		t.Error("expected different directories for different types")
+	}
+	if cache.typeDir(contributor) != cache.typeDir((&CCMContributor{}).Spec()) {
+		t.Error("expected same directory for same type")
+	}
+	if !strings.HasPrefix(filepath.Base(cache.typeDir(contributor)), "CCMContributor-") {
+		t.Errorf("unexpected directory %s", cache.typeDir(contributor))
+	}
+}
+
+func TestCCMDiskCacheClear(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewCCMDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = cache.store((&CCMContributor{}).Spec(), "_a", ccmTestElement("_a", "_s1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = cache.Clear()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected empty directory, got %d entries", len(entries))
+	}
+}
+
+func TestCCMDiskCacheLoadElement(t *testing.T) {
+	var stateRequests, getRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		if strings.HasSuffix(r.URL.Query().Get("fields"), "/(stateId)") {
+			stateRequests++
+			_, _ = fmt.Fprint(w, `<foundation><contributor><stateId>_s1</stateId></contributor></foundation>`)
+			return
+		}
+		getRequests++
+		_, _ = fmt.Fprint(w, `<foundation><contributor><itemId>_a</itemId><stateId>_s1</stateId>
+<name>User</name></contributor></foundation>`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL+"/", "user", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.CCM.DiskCache, err = NewCCMDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		contributor, err := CCMGet[*CCMContributor](context.Background(), client.CCM, "_a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if contributor.Name != "User" {
+			t.Errorf("got name %s", contributor.Name)
+		}
+	}
+
+	// second get only requests the state
+	if stateRequests != 2 || getRequests != 1 {
+		t.Errorf("got %d state and %d get requests, expected 2 and 1", stateRequests, getRequests)
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+)
+
+// ccmTestElement creates an object element with the given item and state ID
+func ccmTestElement(itemId, stateId string) *etree.Element {
+	element := etree.NewElement("contributor")
+	element.CreateElement("itemId").SetText(itemId)
+	element.CreateElement("stateId").SetText(stateId)
+	return element
+}
+
+// ccmTestLoad returns a load function that returns the given element and
+// counts the calls
+func ccmTestLoad(element *etree.Element, calls *int32) func(ctx context.Context) (*etree.Element, error) {
+	return func(ctx context.Context) (*etree.Element, error) {
+		atomic.AddInt32(calls, 1)
+		return element, nil
+	}
+}
+
+func TestCCMCacheGet(t *testing.T) {
+	cache := NewCCMCache(0, 0)
+	spec := (&CCMContributor{}).Spec()
+	element := ccmTestElement("_a", "_s1")
+
+	var calls int32
+	for i := 0; i < 3; i++ {
+		result, err := cache.get(context.Background(), spec, "_a", ccmTestLoad(element, &calls))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != element {
+			t.Errorf("got wrong element")
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 load, got %d", calls)
+	}
+
+	// errors are not cached
+	_, err := cache.get(context.Background(), spec, "_b", func(ctx context.Context) (*etree.Element, error) {
+		return nil, errors.New("failed")
+	})
+	if err == nil {
+		t.Error("expected error of load")
+	}
+	if cache.Len() != 1 {
+		t.Errorf("expected 1 entry, got %d", cache.Len())
+	}
+}
+
+func TestCCMCacheTTL(t *testing.T) {
+	cache := NewCCMCache(time.Millisecond, 0)
+	spec := (&CCMContributor{}).Spec()
+
+	var calls int32
+	load := ccmTestLoad(ccmTestElement("_a", "_s1"), &calls)
+	_, _ = cache.get(context.Background(), spec, "_a", load)
+	time.Sleep(5 * time.Millisecond)
+	_, _ = cache.get(context.Background(), spec, "_a", load)
+
+	if calls != 2 {
+		t.Errorf("expected expired entry to be loaded again, got %d loads", calls)
+	}
+}
+
+func TestCCMCacheLRU(t *testing.T) {
+	cache := NewCCMCache(0, 2)
+	spec := (&CCMContributor{}).Spec()
+
+	var calls int32
+	get := func(itemId string) {
+		_, err := cache.get(context.Background(), spec, itemId,
+			ccmTestLoad(ccmTestElement(itemId, "_s"), &calls))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	get("_a")
+	get("_b")
+	get("_a") // _b is now least recently used
+	get("_c") // removes _b
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", cache.Len())
+	}
+
+	calls = 0
+	get("_a")
+	get("_c")
+	if calls != 0 {
+		t.Errorf("expected recently used entries to be cached, got %d loads", calls)
+	}
+	get("_b")
+	if calls != 1 {
+		t.Errorf("expected least recently used entry to be removed, got %d loads", calls)
+	}
+}
+
+func TestCCMCacheLRUSharedReferences(t *testing.T) {
+	cache := NewCCMCache(0, 1)
+	spec := (&CCMContributor{}).Spec()
+
+	var calls int32
+	get := func(itemId string) {
+		_, err := cache.get(context.Background(), spec, itemId,
+			ccmTestLoad(ccmTestElement(itemId, "_s"), &calls))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	shared := reflect.ValueOf(&CCMContributor{Name: "shared"})
+	cache.reference(spec, "_a", shared)
+	cache.reference(spec, "_b", reflect.ValueOf(&CCMContributor{}))
+	get("_a")
+	get("_c") // removes loaded object of _a
+
+	// references are not limited
+	if cache.Len() != 3 {
+		t.Errorf("expected 3 entries, got %d", cache.Len())
+	}
+
+	// shared instance is kept after the loaded object was removed
+	calls = 0
+	get("_a") // removes _c
+	if calls != 1 {
+		t.Errorf("expected removed object to be loaded again, got %d loads", calls)
+	}
+	if object := cache.reference(spec, "_a", reflect.ValueOf(&CCMContributor{})); object.Interface() != shared.Interface() {
+		t.Error("expected shared instance to be kept")
+	}
+
+	// invalidated references are removed
+	cache.Invalidate("_b")
+	if cache.Len() != 1 {
+		t.Errorf("expected 1 entry, got %d", cache.Len())
+	}
+}
+
+func TestCCMCacheValidate(t *testing.T) {
+	cache := NewCCMCache(0, 0)
+	spec := (&CCMContributor{}).Spec()
+
+	var calls int32
+	_, _ = cache.get(context.Background(), spec, "_a", ccmTestLoad(ccmTestElement("_a", "_s1"), &calls))
+	_, _ = cache.get(context.Background(), spec, "_b", ccmTestLoad(ccmTestElement("_b", "_s1"), &calls))
+
+	// same state is kept
+	cache.Validate("_a", "_s1")
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", cache.Len())
+	}
+
+	// stale state is removed
+	cache.Validate("_a", "_s2")
+	if cache.Len() != 1 {
+		t.Errorf("expected 1 entry, got %d", cache.Len())
+	}
+	if _, ok := cache.lookup(ccmCacheKey(spec, "_a")); ok {
+		t.Error("expected stale entry to be removed")
+	}
+
+	cache.Invalidate("_b")
+	if cache.Len() != 0 {
+		t.Errorf("expected empty cache, got %d entries", cache.Len())
+	}
+}
+
+func TestCCMCacheSingleLoad(t *testing.T) {
+	cache := NewCCMCache(0, 0)
+	spec := (&CCMContributor{}).Spec()
+	element := ccmTestElement("_a", "_s1")
+
+	var calls int32
+	release := make(chan struct{})
+	load := func(ctx context.Context) (*etree.Element, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return element, nil
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := cache.get(context.Background(), spec, "_a", load)
+			if err == nil && result != element {
+				err = errors.New("got wrong element")
+			}
+			errs <- err
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 load, got %d", calls)
+	}
+}
+
+func TestCCMCacheCanceledWaiter(t *testing.T) {
+	cache := NewCCMCache(0, 0)
+	spec := (&CCMContributor{}).Spec()
+	element := ccmTestElement("_a", "_s1")
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	load := func(ctx context.Context) (*etree.Element, error) {
+		close(started)
+		<-release
+
+		// load must not be canceled with the first caller
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return element, nil
+	}
+
+	// first caller starts the load and is canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := cache.get(ctx, spec, "_a", load)
+		firstErr <- err
+	}()
+	<-started
+
+	// second caller waits for the same load
+	secondResult := make(chan *etree.Element, 1)
+	go func() {
+		result, _ := cache.get(context.Background(), spec, "_a", load)
+		secondResult <- result
+	}()
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected canceled first caller, got %v", err)
+	}
+
+	close(release)
+	if result := <-secondResult; result != element {
+		t.Errorf("expected element for second caller, got %v", result)
+	}
+	if _, ok := cache.lookup(ccmCacheKey(spec, "_a")); !ok {
+		t.Error("expected loaded element in cache")
+	}
+}
+
+func TestCCMCacheSharedReferences(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		fields := r.URL.Query().Get("fields")
+
+		switch {
+		case strings.HasPrefix(fields, "workItem/workItem[itemId="):
+			itemId := fields[len("workItem/workItem[itemId=") : len("workItem/workItem[itemId=")+3]
+			_, _ = fmt.Fprintf(w, `<workitem><workItem><itemId>%s</itemId><stateId>_s</stateId>
+<owner><itemId>_user</itemId></owner></workItem></workitem>`, itemId)
+
+		case strings.HasPrefix(fields, "contributor/contributor[itemId=_user]"):
+			atomic.AddInt32(&requests, 1)
+			_, _ = fmt.Fprint(w, `<foundation><contributor><itemId>_user</itemId><stateId>_s</stateId>
+<reportableUrl>url</reportableUrl><name>User</name></contributor></foundation>`)
+
+		default:
+			t.Errorf("unexpected request %s", fields)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL+"/", "user", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.CCM.Cache = NewCCMCache(0, 0)
+
+	first, err := CCMGet[*CCMWorkItem](context.Background(), client.CCM, "_w1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := CCMGet[*CCMWorkItem](context.Background(), client.CCM, "_w2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first.Owner == nil || first.Owner != second.Owner {
+		t.Fatal("expected shared owner instance")
+	}
+	err = first.Owner.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = second.Owner.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Owner.Name != "User" {
+		t.Errorf("expected resolved owner, got %s", second.Owner.Name)
+	}
+	if requests != 1 {
+		t.Errorf("expected owner to be requested once, got %d", requests)
+	}
+
+	// shared instance is dropped with stale state
+	client.CCM.Cache.Validate("_user", "_s2")
+	spec := (&CCMContributor{}).Spec()
+	object := reflect.ValueOf(&CCMContributor{})
+	if client.CCM.Cache.reference(spec, "_user", object).Pointer() != object.Pointer() {
+		t.Error("expected new instance after state change")
+	}
+}
@@ -114,6 +114,33 @@ func (o *CCMProjectArea) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMProjectAreaFields contains the field descriptors of CCMProjectArea
+var CCMProjectAreaFields = struct {
+	ItemId                 CCMField
+	Modified               CCMField
+	ModifiedBy             CCMField
+	Name                   CCMField
+	TeamMembers            CCMField
+	TeamAreaHierarchy      CCMField
+	DevelopmentLines       CCMField
+	ProjectDevelopmentLine CCMField
+	Roles                  CCMField
+	RoleAssignments        CCMField
+	AllTeamAreas           CCMField
+}{
+	ItemId:                 ccmField(goCCMProjectAreaType, "ItemId"),
+	Modified:               ccmField(goCCMProjectAreaType, "Modified"),
+	ModifiedBy:             ccmField(goCCMProjectAreaType, "ModifiedBy"),
+	Name:                   ccmField(goCCMProjectAreaType, "Name"),
+	TeamMembers:            ccmField(goCCMProjectAreaType, "TeamMembers"),
+	TeamAreaHierarchy:      ccmField(goCCMProjectAreaType, "TeamAreaHierarchy"),
+	DevelopmentLines:       ccmField(goCCMProjectAreaType, "DevelopmentLines"),
+	ProjectDevelopmentLine: ccmField(goCCMProjectAreaType, "ProjectDevelopmentLine"),
+	Roles:                  ccmField(goCCMProjectAreaType, "Roles"),
+	RoleAssignments:        ccmField(goCCMProjectAreaType, "RoleAssignments"),
+	AllTeamAreas:           ccmField(goCCMProjectAreaType, "AllTeamAreas"),
+}
+
 // Load CCMProjectArea object
 func (o *CCMProjectArea) Load(ctx context.Context) (err error) {
 	o.init.Do(func() {
@@ -164,6 +191,21 @@ func (o *CCMTeamAreaHierarchyRecord) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMTeamAreaHierarchyRecordFields contains the field descriptors of CCMTeamAreaHierarchyRecord
+var CCMTeamAreaHierarchyRecordFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Parent     CCMField
+	Children   CCMField
+}{
+	ItemId:     ccmField(goCCMTeamAreaHierarchyRecordType, "ItemId"),
+	Modified:   ccmField(goCCMTeamAreaHierarchyRecordType, "Modified"),
+	ModifiedBy: ccmField(goCCMTeamAreaHierarchyRecordType, "ModifiedBy"),
+	Parent:     ccmField(goCCMTeamAreaHierarchyRecordType, "Parent"),
+	Children:   ccmField(goCCMTeamAreaHierarchyRecordType, "Children"),
+}
+
 // LoadAllFields of CCMTeamAreaHierarchyRecord object
 func (o *CCMTeamAreaHierarchyRecord) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -214,6 +256,31 @@ func (o *CCMTeamArea) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMTeamAreaFields contains the field descriptors of CCMTeamArea
+var CCMTeamAreaFields = struct {
+	ItemId          CCMField
+	Modified        CCMField
+	ModifiedBy      CCMField
+	Name            CCMField
+	QualifiedName   CCMField
+	TeamMembers     CCMField
+	ProjectArea     CCMField
+	Roles           CCMField
+	RoleAssignments CCMField
+	ParentTeamArea  CCMField
+}{
+	ItemId:          ccmField(goCCMTeamAreaType, "ItemId"),
+	Modified:        ccmField(goCCMTeamAreaType, "Modified"),
+	ModifiedBy:      ccmField(goCCMTeamAreaType, "ModifiedBy"),
+	Name:            ccmField(goCCMTeamAreaType, "Name"),
+	QualifiedName:   ccmField(goCCMTeamAreaType, "QualifiedName"),
+	TeamMembers:     ccmField(goCCMTeamAreaType, "TeamMembers"),
+	ProjectArea:     ccmField(goCCMTeamAreaType, "ProjectArea"),
+	Roles:           ccmField(goCCMTeamAreaType, "Roles"),
+	RoleAssignments: ccmField(goCCMTeamAreaType, "RoleAssignments"),
+	ParentTeamArea:  ccmField(goCCMTeamAreaType, "ParentTeamArea"),
+}
+
 // Load CCMTeamArea object
 func (o *CCMTeamArea) Load(ctx context.Context) (err error) {
 	o.init.Do(func() {
@@ -264,6 +331,23 @@ func (o *CCMContributor) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMContributorFields contains the field descriptors of CCMContributor
+var CCMContributorFields = struct {
+	ItemId       CCMField
+	Modified     CCMField
+	ModifiedBy   CCMField
+	Name         CCMField
+	EmailAddress CCMField
+	UserId       CCMField
+}{
+	ItemId:       ccmField(goCCMContributorType, "ItemId"),
+	Modified:     ccmField(goCCMContributorType, "Modified"),
+	ModifiedBy:   ccmField(goCCMContributorType, "ModifiedBy"),
+	Name:         ccmField(goCCMContributorType, "Name"),
+	EmailAddress: ccmField(goCCMContributorType, "EmailAddress"),
+	UserId:       ccmField(goCCMContributorType, "UserId"),
+}
+
 // Load CCMContributor object
 func (o *CCMContributor) Load(ctx context.Context) (err error) {
 	o.init.Do(func() {
@@ -325,6 +409,33 @@ func (o *CCMIteration) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMIterationFields contains the field descriptors of CCMIteration
+var CCMIterationFields = struct {
+	ItemId          CCMField
+	Modified        CCMField
+	ModifiedBy      CCMField
+	Name            CCMField
+	Id              CCMField
+	StartDate       CCMField
+	EndDate         CCMField
+	Parent          CCMField
+	Children        CCMField
+	DevelopmentLine CCMField
+	HasDeliverable  CCMField
+}{
+	ItemId:          ccmField(goCCMIterationType, "ItemId"),
+	Modified:        ccmField(goCCMIterationType, "Modified"),
+	ModifiedBy:      ccmField(goCCMIterationType, "ModifiedBy"),
+	Name:            ccmField(goCCMIterationType, "Name"),
+	Id:              ccmField(goCCMIterationType, "Id"),
+	StartDate:       ccmField(goCCMIterationType, "StartDate"),
+	EndDate:         ccmField(goCCMIterationType, "EndDate"),
+	Parent:          ccmField(goCCMIterationType, "Parent"),
+	Children:        ccmField(goCCMIterationType, "Children"),
+	DevelopmentLine: ccmField(goCCMIterationType, "DevelopmentLine"),
+	HasDeliverable:  ccmField(goCCMIterationType, "HasDeliverable"),
+}
+
 // Load CCMIteration object
 func (o *CCMIteration) Load(ctx context.Context) (err error) {
 	o.init.Do(func() {
@@ -383,6 +494,29 @@ func (o *CCMDevelopmentLine) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMDevelopmentLineFields contains the field descriptors of CCMDevelopmentLine
+var CCMDevelopmentLineFields = struct {
+	ItemId           CCMField
+	Modified         CCMField
+	ModifiedBy       CCMField
+	Name             CCMField
+	StartDate        CCMField
+	EndDate          CCMField
+	Iterations       CCMField
+	ProjectArea      CCMField
+	CurrentIteration CCMField
+}{
+	ItemId:           ccmField(goCCMDevelopmentLineType, "ItemId"),
+	Modified:         ccmField(goCCMDevelopmentLineType, "Modified"),
+	ModifiedBy:       ccmField(goCCMDevelopmentLineType, "ModifiedBy"),
+	Name:             ccmField(goCCMDevelopmentLineType, "Name"),
+	StartDate:        ccmField(goCCMDevelopmentLineType, "StartDate"),
+	EndDate:          ccmField(goCCMDevelopmentLineType, "EndDate"),
+	Iterations:       ccmField(goCCMDevelopmentLineType, "Iterations"),
+	ProjectArea:      ccmField(goCCMDevelopmentLineType, "ProjectArea"),
+	CurrentIteration: ccmField(goCCMDevelopmentLineType, "CurrentIteration"),
+}
+
 // Load CCMDevelopmentLine object
 func (o *CCMDevelopmentLine) Load(ctx context.Context) (err error) {
 	o.init.Do(func() {
@@ -436,6 +570,23 @@ func (o *CCMAuditableLink) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMAuditableLinkFields contains the field descriptors of CCMAuditableLink
+var CCMAuditableLinkFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Name       CCMField
+	SourceRef  CCMField
+	TargetRef  CCMField
+}{
+	ItemId:     ccmField(goCCMAuditableLinkType, "ItemId"),
+	Modified:   ccmField(goCCMAuditableLinkType, "Modified"),
+	ModifiedBy: ccmField(goCCMAuditableLinkType, "ModifiedBy"),
+	Name:       ccmField(goCCMAuditableLinkType, "Name"),
+	SourceRef:  ccmField(goCCMAuditableLinkType, "SourceRef"),
+	TargetRef:  ccmField(goCCMAuditableLinkType, "TargetRef"),
+}
+
 // Load CCMAuditableLink object
 func (o *CCMAuditableLink) Load(ctx context.Context) (err error) {
 	o.init.Do(func() {
@@ -500,6 +651,29 @@ func (o *CCMReference) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMReferenceFields contains the field descriptors of CCMReference
+var CCMReferenceFields = struct {
+	ItemId         CCMField
+	Modified       CCMField
+	ModifiedBy     CCMField
+	Comment        CCMField
+	ReferenceType  CCMField
+	Uri            CCMField
+	ReferencedItem CCMField
+	ExtraInfo      CCMField
+	ContentType    CCMField
+}{
+	ItemId:         ccmField(goCCMReferenceType, "ItemId"),
+	Modified:       ccmField(goCCMReferenceType, "Modified"),
+	ModifiedBy:     ccmField(goCCMReferenceType, "ModifiedBy"),
+	Comment:        ccmField(goCCMReferenceType, "Comment"),
+	ReferenceType:  ccmField(goCCMReferenceType, "ReferenceType"),
+	Uri:            ccmField(goCCMReferenceType, "Uri"),
+	ReferencedItem: ccmField(goCCMReferenceType, "ReferencedItem"),
+	ExtraInfo:      ccmField(goCCMReferenceType, "ExtraInfo"),
+	ContentType:    ccmField(goCCMReferenceType, "ContentType"),
+}
+
 // LoadAllFields of CCMReference object
 func (o *CCMReference) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -536,6 +710,21 @@ func (o *CCMReferenceType) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMReferenceTypeFields contains the field descriptors of CCMReferenceType
+var CCMReferenceTypeFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Literal    CCMField
+	Value      CCMField
+}{
+	ItemId:     ccmField(goCCMReferenceTypeType, "ItemId"),
+	Modified:   ccmField(goCCMReferenceTypeType, "Modified"),
+	ModifiedBy: ccmField(goCCMReferenceTypeType, "ModifiedBy"),
+	Literal:    ccmField(goCCMReferenceTypeType, "Literal"),
+	Value:      ccmField(goCCMReferenceTypeType, "Value"),
+}
+
 // LoadAllFields of CCMReferenceType object
 func (o *CCMReferenceType) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -570,6 +759,21 @@ func (o *CCMReadAccess) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMReadAccessFields contains the field descriptors of CCMReadAccess
+var CCMReadAccessFields = struct {
+	ItemId               CCMField
+	Modified             CCMField
+	ModifiedBy           CCMField
+	ContributorItemId    CCMField
+	ContributorContextId CCMField
+}{
+	ItemId:               ccmField(goCCMReadAccessType, "ItemId"),
+	Modified:             ccmField(goCCMReadAccessType, "Modified"),
+	ModifiedBy:           ccmField(goCCMReadAccessType, "ModifiedBy"),
+	ContributorItemId:    ccmField(goCCMReadAccessType, "ContributorItemId"),
+	ContributorContextId: ccmField(goCCMReadAccessType, "ContributorContextId"),
+}
+
 // Load CCMReadAccess object
 func (o *CCMReadAccess) Load(ctx context.Context) (err error) {
 	o.init.Do(func() {
@@ -614,6 +818,23 @@ func (o *CCMRole) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMRoleFields contains the field descriptors of CCMRole
+var CCMRoleFields = struct {
+	ItemId      CCMField
+	Modified    CCMField
+	ModifiedBy  CCMField
+	Id          CCMField
+	Name        CCMField
+	Description CCMField
+}{
+	ItemId:      ccmField(goCCMRoleType, "ItemId"),
+	Modified:    ccmField(goCCMRoleType, "Modified"),
+	ModifiedBy:  ccmField(goCCMRoleType, "ModifiedBy"),
+	Id:          ccmField(goCCMRoleType, "Id"),
+	Name:        ccmField(goCCMRoleType, "Name"),
+	Description: ccmField(goCCMRoleType, "Description"),
+}
+
 // LoadAllFields of CCMRole object
 func (o *CCMRole) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -645,6 +866,21 @@ func (o *CCMRoleAssignment) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMRoleAssignmentFields contains the field descriptors of CCMRoleAssignment
+var CCMRoleAssignmentFields = struct {
+	ItemId           CCMField
+	Modified         CCMField
+	ModifiedBy       CCMField
+	Contributor      CCMField
+	ContributorRoles CCMField
+}{
+	ItemId:           ccmField(goCCMRoleAssignmentType, "ItemId"),
+	Modified:         ccmField(goCCMRoleAssignmentType, "Modified"),
+	ModifiedBy:       ccmField(goCCMRoleAssignmentType, "ModifiedBy"),
+	Contributor:      ccmField(goCCMRoleAssignmentType, "Contributor"),
+	ContributorRoles: ccmField(goCCMRoleAssignmentType, "ContributorRoles"),
+}
+
 // LoadAllFields of CCMRoleAssignment object
 func (o *CCMRoleAssignment) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -692,6 +928,29 @@ func (o *CCMWorkspace) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMWorkspaceFields contains the field descriptors of CCMWorkspace
+var CCMWorkspaceFields = struct {
+	ItemId      CCMField
+	Modified    CCMField
+	ModifiedBy  CCMField
+	Name        CCMField
+	Stream      CCMField
+	Description CCMField
+	CollectData CCMField
+	Properties  CCMField
+	Contributor CCMField
+}{
+	ItemId:      ccmField(goCCMWorkspaceType, "ItemId"),
+	Modified:    ccmField(goCCMWorkspaceType, "Modified"),
+	ModifiedBy:  ccmField(goCCMWorkspaceType, "ModifiedBy"),
+	Name:        ccmField(goCCMWorkspaceType, "Name"),
+	Stream:      ccmField(goCCMWorkspaceType, "Stream"),
+	Description: ccmField(goCCMWorkspaceType, "Description"),
+	CollectData: ccmField(goCCMWorkspaceType, "CollectData"),
+	Properties:  ccmField(goCCMWorkspaceType, "Properties"),
+	Contributor: ccmField(goCCMWorkspaceType, "Contributor"),
+}
+
 // Load CCMWorkspace object
 func (o *CCMWorkspace) Load(ctx context.Context) (err error) {
 	o.init.Do(func() {
@@ -734,6 +993,19 @@ func (o *CCMProperty) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMPropertyFields contains the field descriptors of CCMProperty
+var CCMPropertyFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Key        CCMField
+}{
+	ItemId:     ccmField(goCCMPropertyType, "ItemId"),
+	Modified:   ccmField(goCCMPropertyType, "Modified"),
+	ModifiedBy: ccmField(goCCMPropertyType, "ModifiedBy"),
+	Key:        ccmField(goCCMPropertyType, "Key"),
+}
+
 // LoadAllFields of CCMProperty object
 func (o *CCMProperty) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -763,6 +1035,19 @@ func (o *CCMComponent) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMComponentFields contains the field descriptors of CCMComponent
+var CCMComponentFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Name       CCMField
+}{
+	ItemId:     ccmField(goCCMComponentType, "ItemId"),
+	Modified:   ccmField(goCCMComponentType, "Modified"),
+	ModifiedBy: ccmField(goCCMComponentType, "ModifiedBy"),
+	Name:       ccmField(goCCMComponentType, "Name"),
+}
+
 // Load CCMComponent object
 func (o *CCMComponent) Load(ctx context.Context) (err error) {
 	o.init.Do(func() {
@@ -805,6 +1090,21 @@ func (o *CCMChangeSet) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMChangeSetFields contains the field descriptors of CCMChangeSet
+var CCMChangeSetFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Comment    CCMField
+	Owner      CCMField
+}{
+	ItemId:     ccmField(goCCMChangeSetType, "ItemId"),
+	Modified:   ccmField(goCCMChangeSetType, "Modified"),
+	ModifiedBy: ccmField(goCCMChangeSetType, "ModifiedBy"),
+	Comment:    ccmField(goCCMChangeSetType, "Comment"),
+	Owner:      ccmField(goCCMChangeSetType, "Owner"),
+}
+
 // Load CCMChangeSet object
 func (o *CCMChangeSet) Load(ctx context.Context) (err error) {
 	o.init.Do(func() {
@@ -854,6 +1154,25 @@ func (o *CCMBuildDefinition) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMBuildDefinitionFields contains the field descriptors of CCMBuildDefinition
+var CCMBuildDefinitionFields = struct {
+	ItemId      CCMField
+	Modified    CCMField
+	ModifiedBy  CCMField
+	Id          CCMField
+	Description CCMField
+	ProjectArea CCMField
+	TeamArea    CCMField
+}{
+	ItemId:      ccmField(goCCMBuildDefinitionType, "ItemId"),
+	Modified:    ccmField(goCCMBuildDefinitionType, "Modified"),
+	ModifiedBy:  ccmField(goCCMBuildDefinitionType, "ModifiedBy"),
+	Id:          ccmField(goCCMBuildDefinitionType, "Id"),
+	Description: ccmField(goCCMBuildDefinitionType, "Description"),
+	ProjectArea: ccmField(goCCMBuildDefinitionType, "ProjectArea"),
+	TeamArea:    ccmField(goCCMBuildDefinitionType, "TeamArea"),
+}
+
 // Load CCMBuildDefinition object
 func (o *CCMBuildDefinition) Load(ctx context.Context) (err error) {
 	o.init.Do(func() {
@@ -928,6 +1247,41 @@ func (o *CCMBuildResult) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMBuildResultFields contains the field descriptors of CCMBuildResult
+var CCMBuildResultFields = struct {
+	ItemId          CCMField
+	Modified        CCMField
+	ModifiedBy      CCMField
+	BuildStatus     CCMField
+	BuildState      CCMField
+	Label           CCMField
+	TimeTaken       CCMField
+	PersonalBuild   CCMField
+	StartTime       CCMField
+	TimeWaiting     CCMField
+	BuildDefinition CCMField
+	Creator         CCMField
+	BuildEngine     CCMField
+	UnitTestResults CCMField
+	UnitTestEvents  CCMField
+}{
+	ItemId:          ccmField(goCCMBuildResultType, "ItemId"),
+	Modified:        ccmField(goCCMBuildResultType, "Modified"),
+	ModifiedBy:      ccmField(goCCMBuildResultType, "ModifiedBy"),
+	BuildStatus:     ccmField(goCCMBuildResultType, "BuildStatus"),
+	BuildState:      ccmField(goCCMBuildResultType, "BuildState"),
+	Label:           ccmField(goCCMBuildResultType, "Label"),
+	TimeTaken:       ccmField(goCCMBuildResultType, "TimeTaken"),
+	PersonalBuild:   ccmField(goCCMBuildResultType, "PersonalBuild"),
+	StartTime:       ccmField(goCCMBuildResultType, "StartTime"),
+	TimeWaiting:     ccmField(goCCMBuildResultType, "TimeWaiting"),
+	BuildDefinition: ccmField(goCCMBuildResultType, "BuildDefinition"),
+	Creator:         ccmField(goCCMBuildResultType, "Creator"),
+	BuildEngine:     ccmField(goCCMBuildResultType, "BuildEngine"),
+	UnitTestResults: ccmField(goCCMBuildResultType, "UnitTestResults"),
+	UnitTestEvents:  ccmField(goCCMBuildResultType, "UnitTestEvents"),
+}
+
 // Load CCMBuildResult object
 func (o *CCMBuildResult) Load(ctx context.Context) (err error) {
 	o.init.Do(func() {
@@ -981,6 +1335,23 @@ func (o *CCMCompilationResult) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMCompilationResultFields contains the field descriptors of CCMCompilationResult
+var CCMCompilationResultFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Component  CCMField
+	Errors     CCMField
+	Warnings   CCMField
+}{
+	ItemId:     ccmField(goCCMCompilationResultType, "ItemId"),
+	Modified:   ccmField(goCCMCompilationResultType, "Modified"),
+	ModifiedBy: ccmField(goCCMCompilationResultType, "ModifiedBy"),
+	Component:  ccmField(goCCMCompilationResultType, "Component"),
+	Errors:     ccmField(goCCMCompilationResultType, "Errors"),
+	Warnings:   ccmField(goCCMCompilationResultType, "Warnings"),
+}
+
 // LoadAllFields of CCMCompilationResult object
 func (o *CCMCompilationResult) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -1024,6 +1395,25 @@ func (o *CCMUnitTestResult) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMUnitTestResultFields contains the field descriptors of CCMUnitTestResult
+var CCMUnitTestResultFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Component  CCMField
+	Tests      CCMField
+	Failures   CCMField
+	Errors     CCMField
+}{
+	ItemId:     ccmField(goCCMUnitTestResultType, "ItemId"),
+	Modified:   ccmField(goCCMUnitTestResultType, "Modified"),
+	ModifiedBy: ccmField(goCCMUnitTestResultType, "ModifiedBy"),
+	Component:  ccmField(goCCMUnitTestResultType, "Component"),
+	Tests:      ccmField(goCCMUnitTestResultType, "Tests"),
+	Failures:   ccmField(goCCMUnitTestResultType, "Failures"),
+	Errors:     ccmField(goCCMUnitTestResultType, "Errors"),
+}
+
 // LoadAllFields of CCMUnitTestResult object
 func (o *CCMUnitTestResult) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -1061,6 +1451,23 @@ func (o *CCMUnitTestEvent) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMUnitTestEventFields contains the field descriptors of CCMUnitTestEvent
+var CCMUnitTestEventFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Component  CCMField
+	Test       CCMField
+	Event      CCMField
+}{
+	ItemId:     ccmField(goCCMUnitTestEventType, "ItemId"),
+	Modified:   ccmField(goCCMUnitTestEventType, "Modified"),
+	ModifiedBy: ccmField(goCCMUnitTestEventType, "ModifiedBy"),
+	Component:  ccmField(goCCMUnitTestEventType, "Component"),
+	Test:       ccmField(goCCMUnitTestEventType, "Test"),
+	Event:      ccmField(goCCMUnitTestEventType, "Event"),
+}
+
 // LoadAllFields of CCMUnitTestEvent object
 func (o *CCMUnitTestEvent) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -1090,6 +1497,19 @@ func (o *CCMBuildEngine) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMBuildEngineFields contains the field descriptors of CCMBuildEngine
+var CCMBuildEngineFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Id         CCMField
+}{
+	ItemId:     ccmField(goCCMBuildEngineType, "ItemId"),
+	Modified:   ccmField(goCCMBuildEngineType, "Modified"),
+	ModifiedBy: ccmField(goCCMBuildEngineType, "ModifiedBy"),
+	Id:         ccmField(goCCMBuildEngineType, "Id"),
+}
+
 // Load CCMBuildEngine object
 func (o *CCMBuildEngine) Load(ctx context.Context) (err error) {
 	o.init.Do(func() {
@@ -1304,6 +1724,121 @@ func (o *CCMWorkItem) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMWorkItemFields contains the field descriptors of CCMWorkItem
+var CCMWorkItemFields = struct {
+	ItemId                 CCMField
+	Modified               CCMField
+	ModifiedBy             CCMField
+	Id                     CCMField
+	ResolutionDate         CCMField
+	Summary                CCMField
+	CreationDate           CCMField
+	DueDate                CCMField
+	Description            CCMField
+	WorkflowSurrogate      CCMField
+	Tags                   CCMField
+	Duration               CCMField
+	TimeSpent              CCMField
+	CorrectedEstimate      CCMField
+	DayModified            CCMField
+	Creator                CCMField
+	Owner                  CCMField
+	Category               CCMField
+	Comments               CCMField
+	CustomAttributes       CCMField
+	Subscriptions          CCMField
+	ProjectArea            CCMField
+	Resolver               CCMField
+	Approvals              CCMField
+	ApprovalDescriptors    CCMField
+	Target                 CCMField
+	FoundIn                CCMField
+	ItemHistory            CCMField
+	TeamArea               CCMField
+	State                  CCMField
+	Resolution             CCMField
+	Type                   CCMField
+	Severity               CCMField
+	Priority               CCMField
+	Parent                 CCMField
+	Children               CCMField
+	Blocks                 CCMField
+	DependsOn              CCMField
+	DuplicatedBy           CCMField
+	DuplicateOf            CCMField
+	Related                CCMField
+	ItemExtensions         CCMField
+	MultiItemExtensions    CCMField
+	MediumStringExtensions CCMField
+	BooleanExtensions      CCMField
+	TimestampExtensions    CCMField
+	LongExtensions         CCMField
+	IntExtensions          CCMField
+	BigDecimalExtensions   CCMField
+	LargeStringExtensions  CCMField
+	StringExtensions       CCMField
+	AllExtensions          CCMField
+	TimeSheetEntries       CCMField
+	PlannedStartDate       CCMField
+	PlannedEndDate         CCMField
+}{
+	ItemId:                 ccmField(goCCMWorkItemType, "ItemId"),
+	Modified:               ccmField(goCCMWorkItemType, "Modified"),
+	ModifiedBy:             ccmField(goCCMWorkItemType, "ModifiedBy"),
+	Id:                     ccmField(goCCMWorkItemType, "Id"),
+	ResolutionDate:         ccmField(goCCMWorkItemType, "ResolutionDate"),
+	Summary:                ccmField(goCCMWorkItemType, "Summary"),
+	CreationDate:           ccmField(goCCMWorkItemType, "CreationDate"),
+	DueDate:                ccmField(goCCMWorkItemType, "DueDate"),
+	Description:            ccmField(goCCMWorkItemType, "Description"),
+	WorkflowSurrogate:      ccmField(goCCMWorkItemType, "WorkflowSurrogate"),
+	Tags:                   ccmField(goCCMWorkItemType, "Tags"),
+	Duration:               ccmField(goCCMWorkItemType, "Duration"),
+	TimeSpent:              ccmField(goCCMWorkItemType, "TimeSpent"),
+	CorrectedEstimate:      ccmField(goCCMWorkItemType, "CorrectedEstimate"),
+	DayModified:            ccmField(goCCMWorkItemType, "DayModified"),
+	Creator:                ccmField(goCCMWorkItemType, "Creator"),
+	Owner:                  ccmField(goCCMWorkItemType, "Owner"),
+	Category:               ccmField(goCCMWorkItemType, "Category"),
+	Comments:               ccmField(goCCMWorkItemType, "Comments"),
+	CustomAttributes:       ccmField(goCCMWorkItemType, "CustomAttributes"),
+	Subscriptions:          ccmField(goCCMWorkItemType, "Subscriptions"),
+	ProjectArea:            ccmField(goCCMWorkItemType, "ProjectArea"),
+	Resolver:               ccmField(goCCMWorkItemType, "Resolver"),
+	Approvals:              ccmField(goCCMWorkItemType, "Approvals"),
+	ApprovalDescriptors:    ccmField(goCCMWorkItemType, "ApprovalDescriptors"),
+	Target:                 ccmField(goCCMWorkItemType, "Target"),
+	FoundIn:                ccmField(goCCMWorkItemType, "FoundIn"),
+	ItemHistory:            ccmField(goCCMWorkItemType, "ItemHistory"),
+	TeamArea:               ccmField(goCCMWorkItemType, "TeamArea"),
+	State:                  ccmField(goCCMWorkItemType, "State"),
+	Resolution:             ccmField(goCCMWorkItemType, "Resolution"),
+	Type:                   ccmField(goCCMWorkItemType, "Type"),
+	Severity:               ccmField(goCCMWorkItemType, "Severity"),
+	Priority:               ccmField(goCCMWorkItemType, "Priority"),
+	Parent:                 ccmField(goCCMWorkItemType, "Parent"),
+	Children:               ccmField(goCCMWorkItemType, "Children"),
+	Blocks:                 ccmField(goCCMWorkItemType, "Blocks"),
+	DependsOn:              ccmField(goCCMWorkItemType, "DependsOn"),
+	DuplicatedBy:           ccmField(goCCMWorkItemType, "DuplicatedBy"),
+	DuplicateOf:            ccmField(goCCMWorkItemType, "DuplicateOf"),
+	Related:                ccmField(goCCMWorkItemType, "Related"),
+	ItemExtensions:         ccmField(goCCMWorkItemType, "ItemExtensions"),
+	MultiItemExtensions:    ccmField(goCCMWorkItemType, "MultiItemExtensions"),
+	MediumStringExtensions: ccmField(goCCMWorkItemType, "MediumStringExtensions"),
+	BooleanExtensions:      ccmField(goCCMWorkItemType, "BooleanExtensions"),
+	TimestampExtensions:    ccmField(goCCMWorkItemType, "TimestampExtensions"),
+	LongExtensions:         ccmField(goCCMWorkItemType, "LongExtensions"),
+	IntExtensions:          ccmField(goCCMWorkItemType, "IntExtensions"),
+	BigDecimalExtensions:   ccmField(goCCMWorkItemType, "BigDecimalExtensions"),
+	LargeStringExtensions:  ccmField(goCCMWorkItemType, "LargeStringExtensions"),
+	StringExtensions:       ccmField(goCCMWorkItemType, "StringExtensions"),
+	AllExtensions:          ccmField(goCCMWorkItemType, "AllExtensions"),
+	TimeSheetEntries:       ccmField(goCCMWorkItemType, "TimeSheetEntries"),
+	PlannedStartDate:       ccmField(goCCMWorkItemType, "PlannedStartDate"),
+	PlannedEndDate:         ccmField(goCCMWorkItemType, "PlannedEndDate"),
+}
+
 // Load CCMWorkItem object
 func (o *CCMWorkItem) Load(ctx context.Context) (err error) {
 	o.init.Do(func() {
@@ -1390,6 +1925,25 @@ func (o *CCMComment) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMCommentFields contains the field descriptors of CCMComment
+var CCMCommentFields = struct {
+	ItemId       CCMField
+	Modified     CCMField
+	ModifiedBy   CCMField
+	CreationDate CCMField
+	Content      CCMField
+	Edited       CCMField
+	Creator      CCMField
+}{
+	ItemId:       ccmField(goCCMCommentType, "ItemId"),
+	Modified:     ccmField(goCCMCommentType, "Modified"),
+	ModifiedBy:   ccmField(goCCMCommentType, "ModifiedBy"),
+	CreationDate: ccmField(goCCMCommentType, "CreationDate"),
+	Content:      ccmField(goCCMCommentType, "Content"),
+	Edited:       ccmField(goCCMCommentType, "Edited"),
+	Creator:      ccmField(goCCMCommentType, "Creator"),
+}
+
 // LoadAllFields of CCMComment object
 func (o *CCMComment) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -1430,6 +1984,25 @@ func (o *CCMAttribute) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMAttributeFields contains the field descriptors of CCMAttribute
+var CCMAttributeFields = struct {
+	ItemId        CCMField
+	Modified      CCMField
+	ModifiedBy    CCMField
+	Identifier    CCMField
+	AttributeType CCMField
+	BuiltIn       CCMField
+	ProjectArea   CCMField
+}{
+	ItemId:        ccmField(goCCMAttributeType, "ItemId"),
+	Modified:      ccmField(goCCMAttributeType, "Modified"),
+	ModifiedBy:    ccmField(goCCMAttributeType, "ModifiedBy"),
+	Identifier:    ccmField(goCCMAttributeType, "Identifier"),
+	AttributeType: ccmField(goCCMAttributeType, "AttributeType"),
+	BuiltIn:       ccmField(goCCMAttributeType, "BuiltIn"),
+	ProjectArea:   ccmField(goCCMAttributeType, "ProjectArea"),
+}
+
 // LoadAllFields of CCMAttribute object
 func (o *CCMAttribute) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -1470,6 +2043,25 @@ func (o *CCMApproval) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMApprovalFields contains the field descriptors of CCMApproval
+var CCMApprovalFields = struct {
+	ItemId          CCMField
+	Modified        CCMField
+	ModifiedBy      CCMField
+	StateIdentifier CCMField
+	StateDate       CCMField
+	StateName       CCMField
+	Approver        CCMField
+}{
+	ItemId:          ccmField(goCCMApprovalType, "ItemId"),
+	Modified:        ccmField(goCCMApprovalType, "Modified"),
+	ModifiedBy:      ccmField(goCCMApprovalType, "ModifiedBy"),
+	StateIdentifier: ccmField(goCCMApprovalType, "StateIdentifier"),
+	StateDate:       ccmField(goCCMApprovalType, "StateDate"),
+	StateName:       ccmField(goCCMApprovalType, "StateName"),
+	Approver:        ccmField(goCCMApprovalType, "Approver"),
+}
+
 // LoadAllFields of CCMApproval object
 func (o *CCMApproval) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -1524,6 +2116,33 @@ func (o *CCMApprovalDescriptor) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMApprovalDescriptorFields contains the field descriptors of CCMApprovalDescriptor
+var CCMApprovalDescriptorFields = struct {
+	ItemId                    CCMField
+	Modified                  CCMField
+	ModifiedBy                CCMField
+	Id                        CCMField
+	TypeIdentifier            CCMField
+	TypeName                  CCMField
+	Name                      CCMField
+	CumulativeStateIdentifier CCMField
+	CumulativeStateName       CCMField
+	DueDate                   CCMField
+	Approvals                 CCMField
+}{
+	ItemId:                    ccmField(goCCMApprovalDescriptorType, "ItemId"),
+	Modified:                  ccmField(goCCMApprovalDescriptorType, "Modified"),
+	ModifiedBy:                ccmField(goCCMApprovalDescriptorType, "ModifiedBy"),
+	Id:                        ccmField(goCCMApprovalDescriptorType, "Id"),
+	TypeIdentifier:            ccmField(goCCMApprovalDescriptorType, "TypeIdentifier"),
+	TypeName:                  ccmField(goCCMApprovalDescriptorType, "TypeName"),
+	Name:                      ccmField(goCCMApprovalDescriptorType, "Name"),
+	CumulativeStateIdentifier: ccmField(goCCMApprovalDescriptorType, "CumulativeStateIdentifier"),
+	CumulativeStateName:       ccmField(goCCMApprovalDescriptorType, "CumulativeStateName"),
+	DueDate:                   ccmField(goCCMApprovalDescriptorType, "DueDate"),
+	Approvals:                 ccmField(goCCMApprovalDescriptorType, "Approvals"),
+}
+
 // LoadAllFields of CCMApprovalDescriptor object
 func (o *CCMApprovalDescriptor) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -1567,6 +2186,23 @@ func (o *CCMState) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMStateFields contains the field descriptors of CCMState
+var CCMStateFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Id         CCMField
+	Name       CCMField
+	Group      CCMField
+}{
+	ItemId:     ccmField(goCCMStateType, "ItemId"),
+	Modified:   ccmField(goCCMStateType, "Modified"),
+	ModifiedBy: ccmField(goCCMStateType, "ModifiedBy"),
+	Id:         ccmField(goCCMStateType, "Id"),
+	Name:       ccmField(goCCMStateType, "Name"),
+	Group:      ccmField(goCCMStateType, "Group"),
+}
+
 // LoadAllFields of CCMState object
 func (o *CCMState) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -1602,6 +2238,21 @@ func (o *CCMResolution) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMResolutionFields contains the field descriptors of CCMResolution
+var CCMResolutionFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Id         CCMField
+	Name       CCMField
+}{
+	ItemId:     ccmField(goCCMResolutionType, "ItemId"),
+	Modified:   ccmField(goCCMResolutionType, "Modified"),
+	ModifiedBy: ccmField(goCCMResolutionType, "ModifiedBy"),
+	Id:         ccmField(goCCMResolutionType, "Id"),
+	Name:       ccmField(goCCMResolutionType, "Name"),
+}
+
 // LoadAllFields of CCMResolution object
 func (o *CCMResolution) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -1636,6 +2287,21 @@ func (o *CCMWorkItemType) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMWorkItemTypeFields contains the field descriptors of CCMWorkItemType
+var CCMWorkItemTypeFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Id         CCMField
+	Name       CCMField
+}{
+	ItemId:     ccmField(goCCMWorkItemTypeType, "ItemId"),
+	Modified:   ccmField(goCCMWorkItemTypeType, "Modified"),
+	ModifiedBy: ccmField(goCCMWorkItemTypeType, "ModifiedBy"),
+	Id:         ccmField(goCCMWorkItemTypeType, "Id"),
+	Name:       ccmField(goCCMWorkItemTypeType, "Name"),
+}
+
 // LoadAllFields of CCMWorkItemType object
 func (o *CCMWorkItemType) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -1671,6 +2337,21 @@ func (o *CCMLiteral) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMLiteralFields contains the field descriptors of CCMLiteral
+var CCMLiteralFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Id         CCMField
+	Name       CCMField
+}{
+	ItemId:     ccmField(goCCMLiteralType, "ItemId"),
+	Modified:   ccmField(goCCMLiteralType, "Modified"),
+	ModifiedBy: ccmField(goCCMLiteralType, "ModifiedBy"),
+	Id:         ccmField(goCCMLiteralType, "Id"),
+	Name:       ccmField(goCCMLiteralType, "Name"),
+}
+
 // LoadAllFields of CCMLiteral object
 func (o *CCMLiteral) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -1711,6 +2392,25 @@ func (o *CCMCategory) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMCategoryFields contains the field descriptors of CCMCategory
+var CCMCategoryFields = struct {
+	ItemId        CCMField
+	Modified      CCMField
+	ModifiedBy    CCMField
+	Id            CCMField
+	Name          CCMField
+	Description   CCMField
+	QualifiedName CCMField
+}{
+	ItemId:        ccmField(goCCMCategoryType, "ItemId"),
+	Modified:      ccmField(goCCMCategoryType, "Modified"),
+	ModifiedBy:    ccmField(goCCMCategoryType, "ModifiedBy"),
+	Id:            ccmField(goCCMCategoryType, "Id"),
+	Name:          ccmField(goCCMCategoryType, "Name"),
+	Description:   ccmField(goCCMCategoryType, "Description"),
+	QualifiedName: ccmField(goCCMCategoryType, "QualifiedName"),
+}
+
 // Load CCMCategory object
 func (o *CCMCategory) Load(ctx context.Context) (err error) {
 	o.init.Do(func() {
@@ -1765,6 +2465,27 @@ func (o *CCMDeliverable) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMDeliverableFields contains the field descriptors of CCMDeliverable
+var CCMDeliverableFields = struct {
+	ItemId       CCMField
+	Modified     CCMField
+	ModifiedBy   CCMField
+	Name         CCMField
+	Description  CCMField
+	CreationDate CCMField
+	ProjectArea  CCMField
+	Artifact     CCMField
+}{
+	ItemId:       ccmField(goCCMDeliverableType, "ItemId"),
+	Modified:     ccmField(goCCMDeliverableType, "Modified"),
+	ModifiedBy:   ccmField(goCCMDeliverableType, "ModifiedBy"),
+	Name:         ccmField(goCCMDeliverableType, "Name"),
+	Description:  ccmField(goCCMDeliverableType, "Description"),
+	CreationDate: ccmField(goCCMDeliverableType, "CreationDate"),
+	ProjectArea:  ccmField(goCCMDeliverableType, "ProjectArea"),
+	Artifact:     ccmField(goCCMDeliverableType, "Artifact"),
+}
+
 // Load CCMDeliverable object
 func (o *CCMDeliverable) Load(ctx context.Context) (err error) {
 	o.init.Do(func() {
@@ -1851,6 +2572,43 @@ func (o *CCMExtensionEntry) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMExtensionEntryFields contains the field descriptors of CCMExtensionEntry
+var CCMExtensionEntryFields = struct {
+	ItemId            CCMField
+	Modified          CCMField
+	ModifiedBy        CCMField
+	Key               CCMField
+	Type              CCMField
+	BooleanValue      CCMField
+	IntegerValue      CCMField
+	LongValue         CCMField
+	DoubleValue       CCMField
+	SmallStringValue  CCMField
+	MediumStringValue CCMField
+	LargeStringValue  CCMField
+	TimestampValue    CCMField
+	DecimalValue      CCMField
+	ItemValue         CCMField
+	ItemList          CCMField
+}{
+	ItemId:            ccmField(goCCMExtensionEntryType, "ItemId"),
+	Modified:          ccmField(goCCMExtensionEntryType, "Modified"),
+	ModifiedBy:        ccmField(goCCMExtensionEntryType, "ModifiedBy"),
+	Key:               ccmField(goCCMExtensionEntryType, "Key"),
+	Type:              ccmField(goCCMExtensionEntryType, "Type"),
+	BooleanValue:      ccmField(goCCMExtensionEntryType, "BooleanValue"),
+	IntegerValue:      ccmField(goCCMExtensionEntryType, "IntegerValue"),
+	LongValue:         ccmField(goCCMExtensionEntryType, "LongValue"),
+	DoubleValue:       ccmField(goCCMExtensionEntryType, "DoubleValue"),
+	SmallStringValue:  ccmField(goCCMExtensionEntryType, "SmallStringValue"),
+	MediumStringValue: ccmField(goCCMExtensionEntryType, "MediumStringValue"),
+	LargeStringValue:  ccmField(goCCMExtensionEntryType, "LargeStringValue"),
+	TimestampValue:    ccmField(goCCMExtensionEntryType, "TimestampValue"),
+	DecimalValue:      ccmField(goCCMExtensionEntryType, "DecimalValue"),
+	ItemValue:         ccmField(goCCMExtensionEntryType, "ItemValue"),
+	ItemList:          ccmField(goCCMExtensionEntryType, "ItemList"),
+}
+
 // LoadAllFields of CCMExtensionEntry object
 func (o *CCMExtensionEntry) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -1898,6 +2656,29 @@ func (o *CCMTimeSheetEntry) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMTimeSheetEntryFields contains the field descriptors of CCMTimeSheetEntry
+var CCMTimeSheetEntryFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	StartDate  CCMField
+	TimeSpent  CCMField
+	WorkType   CCMField
+	TimeCode   CCMField
+	TimeCodeId CCMField
+	WorkItem   CCMField
+}{
+	ItemId:     ccmField(goCCMTimeSheetEntryType, "ItemId"),
+	Modified:   ccmField(goCCMTimeSheetEntryType, "Modified"),
+	ModifiedBy: ccmField(goCCMTimeSheetEntryType, "ModifiedBy"),
+	StartDate:  ccmField(goCCMTimeSheetEntryType, "StartDate"),
+	TimeSpent:  ccmField(goCCMTimeSheetEntryType, "TimeSpent"),
+	WorkType:   ccmField(goCCMTimeSheetEntryType, "WorkType"),
+	TimeCode:   ccmField(goCCMTimeSheetEntryType, "TimeCode"),
+	TimeCodeId: ccmField(goCCMTimeSheetEntryType, "TimeCodeId"),
+	WorkItem:   ccmField(goCCMTimeSheetEntryType, "WorkItem"),
+}
+
 // Load CCMTimeSheetEntry object
 func (o *CCMTimeSheetEntry) Load(ctx context.Context) (err error) {
 	o.init.Do(func() {
@@ -1946,6 +2727,19 @@ func (o *CCMItem) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMItemFields contains the field descriptors of CCMItem
+var CCMItemFields = struct {
+	Modified   CCMField
+	ModifiedBy CCMField
+	ItemType   CCMField
+	ItemId     CCMField
+}{
+	Modified:   ccmField(goCCMItemType, "Modified"),
+	ModifiedBy: ccmField(goCCMItemType, "ModifiedBy"),
+	ItemType:   ccmField(goCCMItemType, "ItemType"),
+	ItemId:     ccmField(goCCMItemType, "ItemId"),
+}
+
 // LoadAllFields of CCMItem object
 func (o *CCMItem) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -1976,6 +2770,21 @@ func (o *CCMBooleanExtensionEntry) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMBooleanExtensionEntryFields contains the field descriptors of CCMBooleanExtensionEntry
+var CCMBooleanExtensionEntryFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Key        CCMField
+	Value      CCMField
+}{
+	ItemId:     ccmField(goCCMBooleanExtensionEntryType, "ItemId"),
+	Modified:   ccmField(goCCMBooleanExtensionEntryType, "Modified"),
+	ModifiedBy: ccmField(goCCMBooleanExtensionEntryType, "ModifiedBy"),
+	Key:        ccmField(goCCMBooleanExtensionEntryType, "Key"),
+	Value:      ccmField(goCCMBooleanExtensionEntryType, "Value"),
+}
+
 // LoadAllFields of CCMBooleanExtensionEntry object
 func (o *CCMBooleanExtensionEntry) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -2006,6 +2815,21 @@ func (o *CCMIntExtensionEntry) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMIntExtensionEntryFields contains the field descriptors of CCMIntExtensionEntry
+var CCMIntExtensionEntryFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Key        CCMField
+	Value      CCMField
+}{
+	ItemId:     ccmField(goCCMIntExtensionEntryType, "ItemId"),
+	Modified:   ccmField(goCCMIntExtensionEntryType, "Modified"),
+	ModifiedBy: ccmField(goCCMIntExtensionEntryType, "ModifiedBy"),
+	Key:        ccmField(goCCMIntExtensionEntryType, "Key"),
+	Value:      ccmField(goCCMIntExtensionEntryType, "Value"),
+}
+
 // LoadAllFields of CCMIntExtensionEntry object
 func (o *CCMIntExtensionEntry) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -2036,6 +2860,21 @@ func (o *CCMLongExtensionEntry) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMLongExtensionEntryFields contains the field descriptors of CCMLongExtensionEntry
+var CCMLongExtensionEntryFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Key        CCMField
+	Value      CCMField
+}{
+	ItemId:     ccmField(goCCMLongExtensionEntryType, "ItemId"),
+	Modified:   ccmField(goCCMLongExtensionEntryType, "Modified"),
+	ModifiedBy: ccmField(goCCMLongExtensionEntryType, "ModifiedBy"),
+	Key:        ccmField(goCCMLongExtensionEntryType, "Key"),
+	Value:      ccmField(goCCMLongExtensionEntryType, "Value"),
+}
+
 // LoadAllFields of CCMLongExtensionEntry object
 func (o *CCMLongExtensionEntry) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -2066,6 +2905,21 @@ func (o *CCMStringExtensionEntry) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMStringExtensionEntryFields contains the field descriptors of CCMStringExtensionEntry
+var CCMStringExtensionEntryFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Key        CCMField
+	Value      CCMField
+}{
+	ItemId:     ccmField(goCCMStringExtensionEntryType, "ItemId"),
+	Modified:   ccmField(goCCMStringExtensionEntryType, "Modified"),
+	ModifiedBy: ccmField(goCCMStringExtensionEntryType, "ModifiedBy"),
+	Key:        ccmField(goCCMStringExtensionEntryType, "Key"),
+	Value:      ccmField(goCCMStringExtensionEntryType, "Value"),
+}
+
 // LoadAllFields of CCMStringExtensionEntry object
 func (o *CCMStringExtensionEntry) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -2096,6 +2950,21 @@ func (o *CCMMediumStringExtensionEntry) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMMediumStringExtensionEntryFields contains the field descriptors of CCMMediumStringExtensionEntry
+var CCMMediumStringExtensionEntryFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Key        CCMField
+	Value      CCMField
+}{
+	ItemId:     ccmField(goCCMMediumStringExtensionEntryType, "ItemId"),
+	Modified:   ccmField(goCCMMediumStringExtensionEntryType, "Modified"),
+	ModifiedBy: ccmField(goCCMMediumStringExtensionEntryType, "ModifiedBy"),
+	Key:        ccmField(goCCMMediumStringExtensionEntryType, "Key"),
+	Value:      ccmField(goCCMMediumStringExtensionEntryType, "Value"),
+}
+
 // LoadAllFields of CCMMediumStringExtensionEntry object
 func (o *CCMMediumStringExtensionEntry) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -2126,6 +2995,21 @@ func (o *CCMLargeStringExtensionEntry) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMLargeStringExtensionEntryFields contains the field descriptors of CCMLargeStringExtensionEntry
+var CCMLargeStringExtensionEntryFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Key        CCMField
+	Value      CCMField
+}{
+	ItemId:     ccmField(goCCMLargeStringExtensionEntryType, "ItemId"),
+	Modified:   ccmField(goCCMLargeStringExtensionEntryType, "Modified"),
+	ModifiedBy: ccmField(goCCMLargeStringExtensionEntryType, "ModifiedBy"),
+	Key:        ccmField(goCCMLargeStringExtensionEntryType, "Key"),
+	Value:      ccmField(goCCMLargeStringExtensionEntryType, "Value"),
+}
+
 // LoadAllFields of CCMLargeStringExtensionEntry object
 func (o *CCMLargeStringExtensionEntry) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -2156,6 +3040,21 @@ func (o *CCMTimestampExtensionEntry) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMTimestampExtensionEntryFields contains the field descriptors of CCMTimestampExtensionEntry
+var CCMTimestampExtensionEntryFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Key        CCMField
+	Value      CCMField
+}{
+	ItemId:     ccmField(goCCMTimestampExtensionEntryType, "ItemId"),
+	Modified:   ccmField(goCCMTimestampExtensionEntryType, "Modified"),
+	ModifiedBy: ccmField(goCCMTimestampExtensionEntryType, "ModifiedBy"),
+	Key:        ccmField(goCCMTimestampExtensionEntryType, "Key"),
+	Value:      ccmField(goCCMTimestampExtensionEntryType, "Value"),
+}
+
 // LoadAllFields of CCMTimestampExtensionEntry object
 func (o *CCMTimestampExtensionEntry) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -2186,6 +3085,21 @@ func (o *CCMBigDecimalExtensionEntry) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMBigDecimalExtensionEntryFields contains the field descriptors of CCMBigDecimalExtensionEntry
+var CCMBigDecimalExtensionEntryFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Key        CCMField
+	Value      CCMField
+}{
+	ItemId:     ccmField(goCCMBigDecimalExtensionEntryType, "ItemId"),
+	Modified:   ccmField(goCCMBigDecimalExtensionEntryType, "Modified"),
+	ModifiedBy: ccmField(goCCMBigDecimalExtensionEntryType, "ModifiedBy"),
+	Key:        ccmField(goCCMBigDecimalExtensionEntryType, "Key"),
+	Value:      ccmField(goCCMBigDecimalExtensionEntryType, "Value"),
+}
+
 // LoadAllFields of CCMBigDecimalExtensionEntry object
 func (o *CCMBigDecimalExtensionEntry) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -2216,6 +3130,21 @@ func (o *CCMItemExtensionEntry) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMItemExtensionEntryFields contains the field descriptors of CCMItemExtensionEntry
+var CCMItemExtensionEntryFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Key        CCMField
+	Value      CCMField
+}{
+	ItemId:     ccmField(goCCMItemExtensionEntryType, "ItemId"),
+	Modified:   ccmField(goCCMItemExtensionEntryType, "Modified"),
+	ModifiedBy: ccmField(goCCMItemExtensionEntryType, "ModifiedBy"),
+	Key:        ccmField(goCCMItemExtensionEntryType, "Key"),
+	Value:      ccmField(goCCMItemExtensionEntryType, "Value"),
+}
+
 // LoadAllFields of CCMItemExtensionEntry object
 func (o *CCMItemExtensionEntry) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -2247,6 +3176,21 @@ func (o *CCMMultiItemExtensionEntry) Spec() *CCMObjectSpec {
 	}
 }
 
+// CCMMultiItemExtensionEntryFields contains the field descriptors of CCMMultiItemExtensionEntry
+var CCMMultiItemExtensionEntryFields = struct {
+	ItemId     CCMField
+	Modified   CCMField
+	ModifiedBy CCMField
+	Key        CCMField
+	Value      CCMField
+}{
+	ItemId:     ccmField(goCCMMultiItemExtensionEntryType, "ItemId"),
+	Modified:   ccmField(goCCMMultiItemExtensionEntryType, "Modified"),
+	ModifiedBy: ccmField(goCCMMultiItemExtensionEntryType, "ModifiedBy"),
+	Key:        ccmField(goCCMMultiItemExtensionEntryType, "Key"),
+	Value:      ccmField(goCCMMultiItemExtensionEntryType, "Value"),
+}
+
 // LoadAllFields of CCMMultiItemExtensionEntry object
 func (o *CCMMultiItemExtensionEntry) LoadAllFields(ctx context.Context) error {
 	return o.loadFields(ctx,
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/url"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -32,10 +33,190 @@ type CCMFilter map[string][]interface{}
 // CCMRawFilter creates a filter from a raw query
 func CCMRawFilter(query string) CCMFilter {
 	return map[string][]interface{}{
-		"_raw": {query},
+		ccmFilterRaw: {query},
 	}
 }
 
+// special keys of CCMFilter that are not used as field filter
+const (
+	ccmFilterRaw    = "_raw"
+	ccmFilterSelect = "_select"
+	ccmFilterSort   = "_sort"
+)
+
+// ccmSortField is the sort order of listed objects set by CCMFilter.SortBy
+type ccmSortField struct {
+	field     CCMField
+	ascending bool
+}
+
+// CCMField describes a field of a CCM object (see CCM<Type>Fields)
+type CCMField struct {
+	// Name of the Go struct field (used as key in CCMFilter)
+	Name string
+
+	// Path of the field in the reportable REST API
+	Path string
+
+	// Type of the Go struct field
+	Type reflect.Type
+
+	// owner is the type of the object that contains this field
+	owner reflect.Type
+}
+
+// ccmField creates the descriptor of the struct field with the given name
+func ccmField(t reflect.Type, name string) CCMField {
+	field, ok := t.FieldByName(name)
+	if !ok {
+		panic(fmt.Sprintf("no field with name \"%s\" in %s", name, t))
+	}
+	return CCMField{
+		Name: field.Name,
+		Path: field.Tag.Get("jazz"),
+		Type: field.Type,
+
+		owner: t,
+	}
+}
+
+func (f CCMField) String() string {
+	return f.Name
+}
+
+// Filter creates a filter that matches objects with one of the given values
+// in this field
+func (f CCMField) Filter(values ...interface{}) CCMFilter {
+	return CCMFilter{f.Name: values}
+}
+
+// And combines this filter with the given filters (values of the same field
+// are combined with or)
+func (f CCMFilter) And(filters ...CCMFilter) CCMFilter {
+	result := make(CCMFilter, len(f))
+	for key, values := range f {
+		result[key] = append([]interface{}(nil), values...)
+	}
+	for _, filter := range filters {
+		for key, values := range filter {
+			result[key] = append(result[key], values...)
+		}
+	}
+	return result
+}
+
+// Select restricts the fields of listed objects to the given fields. The
+// objects are loaded directly by the list request instead of requesting each
+// object separately. Fields not selected keep their zero value until the
+// object is loaded.
+func (f CCMFilter) Select(fields ...CCMField) CCMFilter {
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		values[i] = field
+	}
+	return f.And(CCMFilter{ccmFilterSelect: values})
+}
+
+// SortBy sorts listed objects by the given field. If called multiple times
+// the objects are sorted by the first field and then by the following ones.
+//
+// Note: Sorting is done after all objects are loaded.
+func (f CCMFilter) SortBy(field CCMField, ascending bool) CCMFilter {
+	return f.And(CCMFilter{ccmFilterSort: {ccmSortField{field, ascending}}})
+}
+
+// selectedFields returns the fields set by CCMFilter.Select
+func (f CCMFilter) selectedFields() []CCMField {
+	fields := make([]CCMField, len(f[ccmFilterSelect]))
+	for i, value := range f[ccmFilterSelect] {
+		fields[i] = value.(CCMField)
+	}
+	return fields
+}
+
+// sortFields returns the sort order set by CCMFilter.SortBy
+func (f CCMFilter) sortFields() []ccmSortField {
+	fields := make([]ccmSortField, len(f[ccmFilterSort]))
+	for i, value := range f[ccmFilterSort] {
+		fields[i] = value.(ccmSortField)
+	}
+	return fields
+}
+
+// without returns a copy of this filter without the given keys
+func (f CCMFilter) without(keys ...string) CCMFilter {
+	result := f.And()
+	for _, key := range keys {
+		delete(result, key)
+	}
+	return result
+}
+
+// ccmSortObjects sorts the objects by the given fields
+func ccmSortObjects[T CCMObject](objects []T, fields []ccmSortField) {
+	sort.SliceStable(objects, func(i, j int) bool {
+		a := reflect.ValueOf(objects[i]).Elem()
+		b := reflect.ValueOf(objects[j]).Elem()
+		for _, field := range fields {
+			result := ccmCompareValues(a.FieldByName(field.field.Name), b.FieldByName(field.field.Name))
+			if result == 0 {
+				continue
+			}
+			if field.ascending {
+				return result < 0
+			}
+			return result > 0
+		}
+		return false
+	})
+}
+
+// ccmCompareValues compares two field values (-1 if a < b, 0 if a == b,
+// 1 if a > b). Unset values are less than all other values and objects are
+// compared by their item ID.
+func ccmCompareValues(a, b reflect.Value) int {
+	if a.Kind() == reflect.Ptr {
+		switch {
+		case a.IsNil() && b.IsNil():
+			return 0
+		case a.IsNil():
+			return -1
+		case b.IsNil():
+			return 1
+		}
+		return ccmCompareValues(a.Elem(), b.Elem())
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareOrdered(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return compareOrdered(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return compareOrdered(a.Float(), b.Float())
+	case reflect.String:
+		return compareOrdered(a.String(), b.String())
+	case reflect.Bool:
+		return compareOrdered(cast.ToInt(a.Bool()), cast.ToInt(b.Bool()))
+	}
+
+	if timeA, ok := a.Interface().(time.Time); ok {
+		return timeA.Compare(b.Interface().(time.Time))
+	}
+	return compareOrdered(ccmValueKey(a), ccmValueKey(b))
+}
+
+// compareOrdered compares two ordered values
+func compareOrdered[T int | int64 | uint64 | float64 | string](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
 // ccmObjectSpecs contains specifications of all supported object types
 var ccmObjectSpecs = make(map[string]*CCMObjectSpec)
 
@@ -75,10 +256,15 @@ func (o *CCMObjectSpec) buildFilterQuery(filter CCMFilter) (string, error) {
 
 	var filterList []string
 	for key, values := range filter {
+		// field selection and sort order are no filter
+		if key == ccmFilterSelect || key == ccmFilterSort {
+			continue
+		}
+
 		orFilter := make([]string, len(values))
 
 		// special handling to pass raw filter queries
-		if key == "_raw" {
+		if key == ccmFilterRaw {
 			for i, value := range values {
 				orFilter[i] = cast.ToString(value)
 			}
@@ -113,6 +299,9 @@ func (o *CCMObjectSpec) buildFilterQuery(filter CCMFilter) (string, error) {
 			filterList = append(filterList, fmt.Sprintf("(%s)", strings.Join(orFilter, " or ")))
 		}
 	}
+	if len(filterList) == 0 {
+		return "", nil
+	}
 	return fmt.Sprintf("[%s]", strings.Join(filterList, " and ")), nil
 }
 
@@ -147,10 +336,45 @@ func (o *CCMObjectSpec) ListURL(filter CCMFilter) (string, error) {
 		return "", fmt.Errorf("failed to build filter: %w", err)
 	}
 
+	// only item and state ID are required if objects are loaded separately
+	fields := []string{"itemId", "stateId"}
+	for _, field := range filter.selectedFields() {
+		loadFields, err := o.selectLoadFields(field)
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, loadFields...)
+	}
+
 	return fmt.Sprintf(
 		"ccm/rpt/repository/%s?fields=%s",
 		o.ResourceID,
-		url.QueryEscape(fmt.Sprintf("%s/%s%s/(itemId)", o.ElementID, o.ElementID, filterQuery))), nil
+		url.QueryEscape(fmt.Sprintf("%s/%s%s/(%s)",
+			o.ElementID, o.ElementID, filterQuery,
+			strings.Join(uniqueStrings(fields), "|")))), nil
+}
+
+// selectLoadFields returns the field selector of a field set by
+// CCMFilter.Select
+func (o *CCMObjectSpec) selectLoadFields(field CCMField) ([]string, error) {
+	structField, err := o.structField(field)
+	if err != nil {
+		return nil, err
+	}
+	return o.getFieldLoadFields(structField, structField.Tag.Get("jazz")), nil
+}
+
+// structField returns the jazz struct field of the given field descriptor
+func (o *CCMObjectSpec) structField(field CCMField) (reflect.StructField, error) {
+	if field.owner != nil && field.owner != o.Type {
+		return reflect.StructField{}, fmt.Errorf("field %s is not part of %s", field.Name, o.Type)
+	}
+
+	structField, ok := o.Type.FieldByName(field.Name)
+	if !ok || structField.Tag.Get("jazz") == "" {
+		return reflect.StructField{}, fmt.Errorf("no field with name \"%s\"", field.Name)
+	}
+	return structField, nil
 }
 
 // GetURL returns the URL to get an object
@@ -163,6 +387,14 @@ func (o *CCMObjectSpec) GetURL(id string) string {
 		strings.Join(o.getLoadFields(o.Type), "|")) // field selector
 }
 
+// StateURL returns the URL to get only the state ID of an object
+func (o *CCMObjectSpec) StateURL(id string) string {
+	return fmt.Sprintf(
+		"ccm/rpt/repository/%s?fields=%s/%s[itemId=%s]/(stateId)",
+		o.ResourceID, o.ElementID, o.ElementID,
+		id)
+}
+
 // getLoadFields for the given CCM object type
 func (o *CCMObjectSpec) getLoadFields(t reflect.Type) []string {
 	fields := make([]string, 0)
@@ -184,45 +416,53 @@ func (o *CCMObjectSpec) getLoadFields(t reflect.Type) []string {
 			continue
 		}
 
-		// skip non jazz elements
-		spec, err := CCMLoadObjectSpec(field.Type)
-		if err != nil {
+		// simple fields are loaded with "*"
+		if _, err := CCMLoadObjectSpec(field.Type); err != nil {
 			simpleFields = true
 			continue
 		}
 
-		// object with an element ID can be loaded later -> only itemId required
-		if spec.ElementID != "" {
-			fields = append(fields, fieldName+"/itemId")
-			continue
-		}
-
-		subFields := spec.getLoadFields(spec.Type)
-		if len(subFields) > 1 {
-			fields = append(fields,
-				fmt.Sprintf("%s/(%s)", fieldName,
-					strings.Join(subFields, "|")))
-		} else {
-			fields = append(fields,
-				fmt.Sprintf("%s/%s", fieldName, subFields[0]))
-		}
+		fields = append(fields, o.getFieldLoadFields(field, fieldName)...)
 	}
 	if simpleFields {
 		fields = append(fields, "*")
 	}
+	return uniqueStrings(fields)
+}
+
+// getFieldLoadFields returns the field selector of a single field
+func (o *CCMObjectSpec) getFieldLoadFields(field reflect.StructField, fieldName string) []string {
+	// non jazz elements are loaded directly
+	spec, err := CCMLoadObjectSpec(field.Type)
+	if err != nil {
+		return []string{fieldName}
+	}
 
-	// make fields unique
-	seen := make(map[string]struct{}, len(fields))
+	// object with an element ID can be loaded later -> only itemId required
+	if spec.ElementID != "" {
+		return []string{fieldName + "/itemId"}
+	}
+
+	subFields := spec.getLoadFields(spec.Type)
+	if len(subFields) > 1 {
+		return []string{fmt.Sprintf("%s/(%s)", fieldName, strings.Join(subFields, "|"))}
+	}
+	return []string{fmt.Sprintf("%s/%s", fieldName, subFields[0])}
+}
+
+// uniqueStrings removes duplicates from the given list (keeps the order)
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
 	j := 0
-	for _, v := range fields {
+	for _, v := range values {
 		if _, ok := seen[v]; ok {
 			continue
 		}
 		seen[v] = struct{}{}
-		fields[j] = v
+		values[j] = v
 		j++
 	}
-	return fields[:j]
+	return values[:j]
 }
 
 // Load object from XML element
@@ -368,10 +608,36 @@ func (o *CCMObjectSpec) loadValue(ccm *CCMApplication, value reflect.Value, valu
 			panic("unknown type")
 		}
 	case reflect.Ptr:
-		value.Set(reflect.New(valueType.Elem()))
-		return o.loadValue(ccm, value.Elem(), valueType.Elem(), element)
+		object := reflect.New(valueType.Elem())
+		err := o.loadValue(ccm, object.Elem(), valueType.Elem(), element)
+		if err != nil {
+			return err
+		}
+
+		value.Set(ccm.sharedReference(object, element))
+		return nil
 
 	default:
 		panic("unknown type")
 	}
 }
+
+// sharedReference returns the instance of a referenced object that is shared
+// by all references in the cache (or the given object if not cached)
+func (a *CCMApplication) sharedReference(object reflect.Value, element *etree.Element) reflect.Value {
+	if a == nil || a.Cache == nil {
+		return object
+	}
+
+	// only loadable objects can be shared
+	spec, err := CCMLoadObjectSpec(object.Type())
+	if err != nil || spec.ElementID == "" {
+		return object
+	}
+
+	itemId := element.SelectElement("itemId")
+	if itemId == nil || itemId.Text() == "" {
+		return object
+	}
+	return a.Cache.reference(spec, itemId.Text(), object)
+}
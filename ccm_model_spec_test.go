@@ -0,0 +1,249 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCCMFieldBaseFields(t *testing.T) {
+	tests := []struct {
+		field CCMField
+		name  string
+		path  string
+	}{
+		{CCMWorkItemFields.ItemId, "ItemId", "itemId"},
+		{CCMWorkItemFields.Modified, "Modified", "modified"},
+		{CCMWorkItemFields.ModifiedBy, "ModifiedBy", "modifiedBy"},
+		{CCMItemFields.ItemId, "ItemId", "itemId"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.field.Name != test.name || test.field.Path != test.path {
+				t.Errorf("got field %s (%s), expected %s (%s)",
+					test.field.Name, test.field.Path, test.name, test.path)
+			}
+		})
+	}
+}
+
+func TestCCMBuildFilterQuery(t *testing.T) {
+	spec := (&CCMWorkItem{}).Spec()
+
+	tests := []struct {
+		name   string
+		filter CCMFilter
+		query  string
+	}{
+		{"empty", nil, ""},
+		{"simple field", CCMWorkItemFields.Summary.Filter("a"), `[summary="a"]`},
+		{"or", CCMWorkItemFields.Id.Filter(1, 2), `[(id="1" or id="2")]`},
+		{"base object field", CCMWorkItemFields.ModifiedBy.Filter("_user"), `[modifiedBy/itemId="_user"]`},
+		{"raw", CCMRawFilter(`id>3`), `[id>3]`},
+		{
+			"select and sort are ignored",
+			CCMFilter{}.Select(CCMWorkItemFields.Summary).SortBy(CCMWorkItemFields.Id, true),
+			"",
+		},
+		{
+			"select with filter",
+			CCMWorkItemFields.Summary.Filter("a").Select(CCMWorkItemFields.Summary),
+			`[summary="a"]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, err := spec.buildFilterQuery(test.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != test.query {
+				t.Errorf("got query %s, expected %s", query, test.query)
+			}
+		})
+	}
+
+	_, err := spec.buildFilterQuery(CCMFilter{"Unknown": {1}})
+	if err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+func TestCCMListURLSelect(t *testing.T) {
+	spec := (&CCMWorkItem{}).Spec()
+
+	tests := []struct {
+		name   string
+		filter CCMFilter
+		fields string
+	}{
+		{"without select", nil, "workItem/workItem/(itemId|stateId)"},
+		{
+			"simple fields",
+			CCMFilter{}.Select(CCMWorkItemFields.Summary, CCMWorkItemFields.Id, CCMWorkItemFields.ItemId),
+			"workItem/workItem/(itemId|stateId|summary|id)",
+		},
+		{
+			"object fields",
+			CCMFilter{}.Select(CCMWorkItemFields.Owner, CCMWorkItemFields.ModifiedBy),
+			"workItem/workItem/(itemId|stateId|owner/itemId|modifiedBy/itemId)",
+		},
+		{
+			"with filter",
+			CCMWorkItemFields.Summary.Filter("a").Select(CCMWorkItemFields.Summary),
+			`workItem/workItem[summary="a"]/(itemId|stateId|summary)`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			listURL, err := spec.ListURL(test.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fields, err := url.QueryUnescape(strings.TrimPrefix(listURL, "ccm/rpt/repository/workitem?fields="))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fields != test.fields {
+				t.Errorf("got fields %s, expected %s", fields, test.fields)
+			}
+		})
+	}
+
+	_, err := spec.ListURL(CCMFilter{}.Select(CCMContributorFields.Name))
+	if err == nil {
+		t.Error("expected error for field of other type")
+	}
+}
+
+// ccmTestListServer creates a server that returns the given work items
+// (id -> summary) for every list request
+func ccmTestListServer(t *testing.T, requests *int32, workItems map[int]string) *CCMApplication {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+
+		var builder strings.Builder
+		builder.WriteString("<workitem>")
+		for id, summary := range workItems {
+			_, _ = fmt.Fprintf(&builder,
+				"<workItem><itemId>_%d</itemId><stateId>_s%d</stateId><id>%d</id><summary>%s</summary></workItem>",
+				id, id, id, summary)
+		}
+		builder.WriteString("</workitem>")
+
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = fmt.Fprint(w, builder.String())
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL+"/", "user", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client.CCM
+}
+
+func TestCCMListSelectSort(t *testing.T) {
+	var requests int32
+	ccm := ccmTestListServer(t, &requests, map[int]string{
+		1: "b",
+		2: "a",
+		3: "b",
+	})
+
+	workItems, err := CCMList[*CCMWorkItem](context.Background(), ccm, CCMFilter{}.
+		Select(CCMWorkItemFields.Id, CCMWorkItemFields.Summary).
+		SortBy(CCMWorkItemFields.Summary, true).
+		SortBy(CCMWorkItemFields.Id, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// objects are loaded from the list request only
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+
+	var order []string
+	for _, workItem := range workItems {
+		order = append(order, fmt.Sprintf("%d%s", workItem.Id, workItem.Summary))
+		if workItem.ReportableUrl != "" {
+			t.Errorf("work item %d must not be marked as loaded", workItem.Id)
+		}
+	}
+	if strings.Join(order, ",") != "2a,3b,1b" {
+		t.Errorf("got order %s, expected 2a,3b,1b", strings.Join(order, ","))
+	}
+}
+
+func TestCCMListSortInvalidField(t *testing.T) {
+	var requests int32
+	ccm := ccmTestListServer(t, &requests, nil)
+
+	_, err := CCMList[*CCMWorkItem](context.Background(), ccm,
+		CCMFilter{}.SortBy(CCMContributorFields.Name, true))
+	if err == nil {
+		t.Error("expected error for sort field of other type")
+	}
+	if requests != 0 {
+		t.Errorf("expected no request, got %d", requests)
+	}
+}
+
+func TestCCMListWorkerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+
+		// loading of objects fails
+		if strings.Contains(r.URL.Query().Get("fields"), "[itemId=") {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = fmt.Fprint(w, "<error>broken</error>")
+			return
+		}
+
+		// endless list with more entries than the workers can buffer
+		var builder strings.Builder
+		_, _ = fmt.Fprintf(&builder, `<workitem href="ccm/rpt/repository/workitem?page=%s1">`,
+			r.URL.Query().Get("page"))
+		for i := 0; i < 100; i++ {
+			_, _ = fmt.Fprintf(&builder, "<workItem><itemId>_%d</itemId></workItem>", i)
+		}
+		builder.WriteString("</workitem>")
+		_, _ = fmt.Fprint(w, builder.String())
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL+"/", "user", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Worker = 1
+
+	// results are never read -> list must stop after the worker failed
+	err = CCMListChan[*CCMWorkItem](context.Background(), client.CCM, nil, make(chan *CCMWorkItem))
+	if err == nil {
+		t.Error("expected error of failed worker")
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SCM REST services (not part of the reportable REST API)
+const (
+	ccmFilesystemService        = "ccm/service/com.ibm.team.filesystem.common.internal.rest.IFilesystemRestService2/"
+	ccmFilesystemContentService = "ccm/service/com.ibm.team.filesystem.service.internal.rest.IFilesystemContentService/"
+)
+
+// link type between work items and change sets
+const ccmChangeSetLinkType = "com.ibm.team.filesystem.workitems.change_set"
+
+// Kinds of changes in a change set (can be combined)
+const (
+	CCMChangeKindAdd      = 1
+	CCMChangeKindModify   = 2
+	CCMChangeKindRename   = 4
+	CCMChangeKindReparent = 8
+	CCMChangeKindDelete   = 16
+)
+
+// CCMFileChange describes the change of a single file or folder in a change set
+type CCMFileChange struct {
+	// Kind of change (combination of CCMChangeKind*)
+	Kind int
+
+	// ItemId of the changed file or folder
+	ItemId string
+
+	// ItemType of the changed item (e.g. file, folder, symbolic link)
+	ItemType string
+
+	// ComponentId of the component containing the item
+	ComponentId string
+
+	// BeforeStateId contains the state of the item before the change
+	// (empty for added items)
+	BeforeStateId string
+
+	// AfterStateId contains the state of the item after the change
+	// (empty for deleted items)
+	AfterStateId string
+
+	// BeforePath contains the path of the item before the change
+	BeforePath string
+
+	// AfterPath contains the path of the item after the change
+	AfterPath string
+
+	// ccm Application instance used for interactions with the server
+	ccm *CCMApplication
+}
+
+// Added returns true if the item was added
+func (c *CCMFileChange) Added() bool {
+	return c.Kind&CCMChangeKindAdd != 0
+}
+
+// Modified returns true if the content of the item was modified
+func (c *CCMFileChange) Modified() bool {
+	return c.Kind&CCMChangeKindModify != 0
+}
+
+// Renamed returns true if the item was renamed or moved
+func (c *CCMFileChange) Renamed() bool {
+	return c.Kind&(CCMChangeKindRename|CCMChangeKindReparent) != 0
+}
+
+// Deleted returns true if the item was deleted
+func (c *CCMFileChange) Deleted() bool {
+	return c.Kind&CCMChangeKindDelete != 0
+}
+
+// Path of the item after the change (or before the change for deleted items)
+func (c *CCMFileChange) Path() string {
+	if c.AfterPath != "" {
+		return c.AfterPath
+	}
+	return c.BeforePath
+}
+
+// DownloadBefore writes the content of the file before the change
+func (c *CCMFileChange) DownloadBefore(ctx context.Context, w io.Writer) error {
+	if c.BeforeStateId == "" {
+		return errors.New("file has no state before the change")
+	}
+	if c.ccm == nil {
+		return errors.New("file change is not bound to a CCM application")
+	}
+	return c.ccm.DownloadFile(ctx, c.ComponentId, c.ItemId, c.BeforeStateId, w)
+}
+
+// DownloadAfter writes the content of the file after the change
+func (c *CCMFileChange) DownloadAfter(ctx context.Context, w io.Writer) error {
+	if c.AfterStateId == "" {
+		return errors.New("file has no state after the change")
+	}
+	if c.ccm == nil {
+		return errors.New("file change is not bound to a CCM application")
+	}
+	return c.ccm.DownloadFile(ctx, c.ComponentId, c.ItemId, c.AfterStateId, w)
+}
+
+// Files returns all file changes of this change set
+func (o *CCMChangeSet) Files(ctx context.Context) ([]*CCMFileChange, error) {
+	if o.ccm == nil {
+		return nil, errors.New("change set is not bound to a CCM application")
+	}
+
+	var value struct {
+		ChangeSet struct {
+			Component struct {
+				ItemId string `json:"itemId"`
+			} `json:"component"`
+		} `json:"changeSet"`
+		Changes []struct {
+			Kind int `json:"kind"`
+			Item struct {
+				ItemId   string `json:"itemId"`
+				ItemType string `json:"itemType"`
+			} `json:"item"`
+			BeforeStateId string `json:"beforeStateId"`
+			AfterStateId  string `json:"afterStateId"`
+			BeforePath    string `json:"beforePath"`
+			AfterPath     string `json:"afterPath"`
+		} `json:"changes"`
+	}
+	err := o.ccm.serviceGet(ctx,
+		ccmFilesystemService+"changeSetPlus?changeSetItemId="+url.QueryEscape(o.ItemId),
+		&value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changes of %s: %w", o.ItemId, err)
+	}
+
+	changes := make([]*CCMFileChange, len(value.Changes))
+	for i, change := range value.Changes {
+		changes[i] = &CCMFileChange{
+			Kind:          change.Kind,
+			ItemId:        change.Item.ItemId,
+			ItemType:      change.Item.ItemType,
+			ComponentId:   value.ChangeSet.Component.ItemId,
+			BeforeStateId: change.BeforeStateId,
+			AfterStateId:  change.AfterStateId,
+			BeforePath:    change.BeforePath,
+			AfterPath:     change.AfterPath,
+			ccm:           o.ccm,
+		}
+	}
+	return changes, nil
+}
+
+// ChangeSets linked to this work item
+func (o *CCMWorkItem) ChangeSets(ctx context.Context) ([]*CCMChangeSet, error) {
+	if o.ccm == nil {
+		return nil, errors.New("work item is not bound to a CCM application")
+	}
+
+	links, err := CCMList[*CCMAuditableLink](ctx, o.ccm, CCMRawFilter(fmt.Sprintf(
+		"name=\"%s\" and (sourceRef/referencedItem/itemId=\"%s\" or targetRef/referencedItem/itemId=\"%s\")",
+		ccmChangeSetLinkType, o.ItemId, o.ItemId)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get change set links: %w", err)
+	}
+
+	changeSets := make([]*CCMChangeSet, 0, len(links))
+	for _, link := range links {
+		// the change set is the side of the link that is not this work item
+		ref := link.SourceRef
+		if ref != nil && ref.ReferencedItem != nil && ref.ReferencedItem.ItemId == o.ItemId {
+			ref = link.TargetRef
+		}
+		if ref == nil || ref.ReferencedItem == nil {
+			continue
+		}
+
+		changeSet, err := CCMGet[*CCMChangeSet](ctx, o.ccm, ref.ReferencedItem.ItemId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get change set: %w", err)
+		}
+		changeSets = append(changeSets, changeSet)
+	}
+	return changeSets, nil
+}
+
+// DownloadFile writes the content of a file at the given state
+func (a *CCMApplication) DownloadFile(ctx context.Context, componentId, itemId, stateId string, w io.Writer) error {
+	query := make(url.Values)
+	query.Set("itemId", itemId)
+	query.Set("stateId", stateId)
+
+	response, err := a.client.get(ctx,
+		fmt.Sprintf("%s-/%s?%s", ccmFilesystemContentService, url.PathEscape(componentId), query.Encode()),
+		"application/octet-stream", false)
+	if err != nil {
+		return fmt.Errorf("failed to get file content: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return errorFromResponse("failed to get file content", response, nil)
+	}
+
+	_, err = io.Copy(w, response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to get file content: %w", err)
+	}
+	return nil
+}
+
+// serviceGet requests a (non reportable) REST service and decodes the
+// returned value of the JSON response
+func (a *CCMApplication) serviceGet(ctx context.Context, url string, value interface{}) error {
+	response, err := a.client.get(ctx, url, "text/json", false)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return decodeServiceResponse(response, value)
+}
+
+// servicePost sends the given form values to a (non reportable) REST service
+// and decodes the returned value of the JSON response (if value is not nil)
+func (a *CCMApplication) servicePost(ctx context.Context, url string, form url.Values, value interface{}) error {
+	// the response is JSON and not form encoded like the request
+	response, err := a.client.postAccept(ctx, url, "application/x-www-form-urlencoded", "text/json",
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return decodeServiceResponse(response, value)
+}
+
+// decodeServiceResponse decodes the returned value of a JSON service response
+func decodeServiceResponse(response *http.Response, value interface{}) error {
+	if response.StatusCode >= 300 {
+		return errorFromResponse("service request failed", response, nil)
+	}
+	if value == nil {
+		return nil
+	}
+
+	var envelope struct {
+		Body struct {
+			Response struct {
+				ReturnValue struct {
+					Value  json.RawMessage `json:"value"`
+					Values json.RawMessage `json:"values"`
+				} `json:"returnValue"`
+			} `json:"response"`
+		} `json:"soapenv:Body"`
+	}
+	err := json.NewDecoder(response.Body).Decode(&envelope)
+	if err != nil {
+		return fmt.Errorf("failed to parse service response: %w", err)
+	}
+
+	data := envelope.Body.Response.ReturnValue.Value
+	if len(data) == 0 {
+		data = envelope.Body.Response.ReturnValue.Values
+	}
+	if len(data) == 0 {
+		return errors.New("empty service response")
+	}
+	return json.Unmarshal(data, value)
+}
@@ -0,0 +1,315 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// ccmTestScmServer returns a CCM application for a server that provides the
+// change set of testdata/ccm/changeSetPlus.json, the links of work item _wi1
+// and the content of the files in component _comp1
+func ccmTestScmServer(t *testing.T) *CCMApplication {
+	itemId := regexp.MustCompile(`itemId=(_\w+)`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + ccmFilesystemService + "changeSetPlus":
+			if r.URL.Query().Get("changeSetItemId") != "_cs1" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			data, err := os.ReadFile("testdata/ccm/changeSetPlus.json")
+			if err != nil {
+				t.Error(err)
+			}
+			w.Header().Set("Content-Type", "text/json")
+			_, _ = w.Write(data)
+
+		case "/" + ccmFilesystemContentService + "-/_comp1":
+			stateId := r.URL.Query().Get("stateId")
+			if stateId == "_missing" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = fmt.Fprintf(w, "%s@%s", r.URL.Query().Get("itemId"), stateId)
+
+		case "/ccm/rpt/repository/foundation":
+			// the work item is once the source and once the target of the link
+			links := map[string]string{
+				"_l1": `<sourceRef><referencedItem><itemId>_wi1</itemId></referencedItem></sourceRef>
+<targetRef><referencedItem><itemId>_cs1</itemId></referencedItem></targetRef>`,
+				"_l2": `<sourceRef><referencedItem><itemId>_cs2</itemId></referencedItem></sourceRef>
+<targetRef><referencedItem><itemId>_wi1</itemId></referencedItem></targetRef>`,
+			}
+
+			fields := r.URL.Query().Get("fields")
+			var ids []string
+			if match := itemId.FindStringSubmatch(fields); match != nil {
+				// load of a single link
+				ids = []string{match[1]}
+			} else if strings.Contains(fields, ccmChangeSetLinkType) && strings.Contains(fields, "_wi1") {
+				ids = []string{"_l1", "_l2"}
+			} else {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/xml")
+			var builder strings.Builder
+			builder.WriteString("<foundation>")
+			for _, id := range ids {
+				_, _ = fmt.Fprintf(&builder,
+					"<auditableLink><itemId>%s</itemId><stateId>%s_s</stateId><name>%s</name>%s</auditableLink>",
+					id, id, ccmChangeSetLinkType, links[id])
+			}
+			builder.WriteString("</foundation>")
+			_, _ = fmt.Fprint(w, builder.String())
+
+		case "/ccm/rpt/repository/scm":
+			match := itemId.FindStringSubmatch(r.URL.Query().Get("fields"))
+			if match == nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = fmt.Fprintf(w, `<scm><changeSet><itemId>%s</itemId><stateId>%s_s</stateId>
+<comment>Change %s</comment></changeSet></scm>`, match[1], match[1], match[1])
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL+"/", "user", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client.CCM
+}
+
+func TestCCMChangeSetFiles(t *testing.T) {
+	ccm := ccmTestScmServer(t)
+
+	changeSet := &CCMChangeSet{}
+	changeSet.ItemId = "_cs1"
+	changeSet.setCCM(ccm)
+
+	changes, err := changeSet.Files(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		itemId   string
+		path     string
+		added    bool
+		modified bool
+		deleted  bool
+		before   string
+		after    string
+	}{
+		{"_file1", "/parser/parser.go", false, true, false, "_file1@_before1", "_file1@_after1"},
+		{"_file2", "/parser/lexer.go", true, false, false, "", "_file2@_after2"},
+		{"_file3", "/parser/old.go", false, false, true, "_file3@_before3", ""},
+	}
+	if len(changes) != len(tests) {
+		t.Fatalf("unexpected number of changes: %d", len(changes))
+	}
+	for i, test := range tests {
+		t.Run(test.itemId, func(t *testing.T) {
+			change := changes[i]
+			if change.ItemId != test.itemId || change.ComponentId != "_comp1" ||
+				change.ItemType != "com.ibm.team.filesystem.FileItem" {
+				t.Fatalf("unexpected change: %+v", change)
+			}
+			if change.Path() != test.path {
+				t.Errorf("unexpected path: %s", change.Path())
+			}
+			if change.Added() != test.added || change.Modified() != test.modified ||
+				change.Deleted() != test.deleted || change.Renamed() {
+				t.Errorf("unexpected kind: %d", change.Kind)
+			}
+
+			var before bytes.Buffer
+			err := change.DownloadBefore(context.Background(), &before)
+			if (err != nil) != (test.before == "") || before.String() != test.before {
+				t.Errorf("unexpected content before: %q (%v)", before.String(), err)
+			}
+
+			var after bytes.Buffer
+			err = change.DownloadAfter(context.Background(), &after)
+			if (err != nil) != (test.after == "") || after.String() != test.after {
+				t.Errorf("unexpected content after: %q (%v)", after.String(), err)
+			}
+		})
+	}
+}
+
+func TestCCMChangeSetFilesErrors(t *testing.T) {
+	ccm := ccmTestScmServer(t)
+
+	changeSet := &CCMChangeSet{}
+	changeSet.ItemId = "_unknown"
+	_, err := changeSet.Files(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "not bound") {
+		t.Errorf("unexpected error for unbound change set: %v", err)
+	}
+
+	changeSet.setCCM(ccm)
+	_, err = changeSet.Files(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "_unknown") {
+		t.Errorf("unexpected error for unknown change set: %v", err)
+	}
+
+	// change created by hand is not bound to a server
+	change := &CCMFileChange{ItemId: "_file1", BeforeStateId: "_before1", AfterStateId: "_after1"}
+	err = change.DownloadBefore(context.Background(), &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "not bound") {
+		t.Errorf("unexpected error for unbound change before: %v", err)
+	}
+	err = change.DownloadAfter(context.Background(), &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "not bound") {
+		t.Errorf("unexpected error for unbound change after: %v", err)
+	}
+
+	err = ccm.DownloadFile(context.Background(), "_comp1", "_file1", "_missing", &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "failed to get file content") {
+		t.Errorf("unexpected error for missing state: %v", err)
+	}
+}
+
+func TestCCMWorkItemChangeSets(t *testing.T) {
+	ccm := ccmTestScmServer(t)
+
+	workItem := &CCMWorkItem{}
+	workItem.ItemId = "_wi1"
+	workItem.setCCM(ccm)
+
+	changeSets, err := workItem.ChangeSets(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var comments []string
+	for _, changeSet := range changeSets {
+		comments = append(comments, changeSet.ItemId+":"+changeSet.Comment)
+	}
+	// links are loaded in parallel -> order is not defined
+	sort.Strings(comments)
+	if strings.Join(comments, ",") != "_cs1:Change _cs1,_cs2:Change _cs2" {
+		t.Errorf("unexpected change sets: %v", comments)
+	}
+
+	_, err = (&CCMWorkItem{}).ChangeSets(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "not bound") {
+		t.Errorf("unexpected error for unbound work item: %v", err)
+	}
+}
+
+func TestCCMServicePost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// form encoded response if JSON is not requested
+		if r.Header.Get("Accept") != "text/json" {
+			w.Header().Set("Content-Type", r.Header.Get("Accept"))
+			_, _ = fmt.Fprint(w, "value=1")
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/json")
+		_, _ = fmt.Fprintf(w, `{"soapenv:Body":{"response":{"returnValue":{"value":{"name":%q}}}}}`,
+			r.FormValue("name"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL+"/", "user", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var value struct {
+		Name string `json:"name"`
+	}
+	err = client.CCM.servicePost(context.Background(), "ccm/service/test", url.Values{"name": {"build"}}, &value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value.Name != "build" {
+		t.Errorf("unexpected value: %+v", value)
+	}
+}
+
+func TestCCMDecodeServiceResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		value  string
+		err    string
+	}{
+		{"value", 200, `{"soapenv:Body":{"response":{"returnValue":{"value":["a"]}}}}`, "a", ""},
+		{"values", 200, `{"soapenv:Body":{"response":{"returnValue":{"values":["a","b"]}}}}`, "a,b", ""},
+		{"empty", 200, `{"soapenv:Body":{"response":{"returnValue":{}}}}`, "", "empty service response"},
+		{"invalid", 200, `value=1`, "", "failed to parse service response"},
+		{"wrong type", 200, `{"soapenv:Body":{"response":{"returnValue":{"value":"a"}}}}`, "", "cannot unmarshal"},
+		{"status", 500, `internal error`, "", "service request failed: 500"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var value []string
+			err := decodeServiceResponse(&http.Response{
+				StatusCode: test.status,
+				Status:     fmt.Sprintf("%d", test.status),
+				Body:       io.NopCloser(strings.NewReader(test.body)),
+			}, &value)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(value, ",") != test.value {
+				t.Errorf("unexpected value: %v", value)
+			}
+		})
+	}
+
+	// value is not decoded if not requested
+	err := decodeServiceResponse(&http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader("value=1")),
+	}, nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
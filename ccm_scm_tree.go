@@ -0,0 +1,310 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SCM REST service (not part of the reportable REST API)
+const ccmScmService = "ccm/service/com.ibm.team.scm.common.internal.rest.IScmRestService/"
+
+// CCMBaseline of an SCM component
+type CCMBaseline struct {
+	// ItemId of the baseline
+	ItemId string `json:"itemId"`
+
+	// Id of the baseline (numeric identifier shown in the UI)
+	Id int `json:"id"`
+
+	// Name of the baseline
+	Name string `json:"name"`
+
+	// Comment of the baseline
+	Comment string `json:"comment"`
+
+	// CreationDate of the baseline
+	CreationDate *time.Time `json:"creationDate"`
+
+	// ComponentId of the component containing the baseline
+	ComponentId string `json:"-"`
+
+	// ccm Application instance used for interactions with the server
+	ccm *CCMApplication
+}
+
+// FileTree of the component in this baseline
+func (b *CCMBaseline) FileTree() *CCMFileNode {
+	return newCCMFileRoot(b.ccm, b.ComponentId, url.Values{"baselineItemId": {b.ItemId}})
+}
+
+// CCMSnapshot of an SCM stream or workspace
+type CCMSnapshot struct {
+	// ItemId of the snapshot
+	ItemId string `json:"itemId"`
+
+	// Name of the snapshot
+	Name string `json:"name"`
+
+	// Comment of the snapshot
+	Comment string `json:"comment"`
+
+	// CreationDate of the snapshot
+	CreationDate *time.Time `json:"creationDate"`
+
+	// Baselines of all components contained in the snapshot
+	Baselines []*CCMBaseline `json:"-"`
+}
+
+// CCMFileNode is a file or folder in the file tree of an SCM component.
+// The children of folders are loaded on first access.
+type CCMFileNode struct {
+	// Name of file or folder
+	Name string
+
+	// Path of file or folder inside the component
+	Path string
+
+	// ItemId of file or folder
+	ItemId string
+
+	// StateId of file or folder
+	StateId string
+
+	// Folder is true for folders
+	Folder bool
+
+	// ComponentId of the component containing the item
+	ComponentId string
+
+	// source of the tree (workspace or baseline)
+	source url.Values
+
+	// loaded children of folder
+	children []*CCMFileNode
+	mutex    sync.Mutex
+
+	// ccm Application instance used for interactions with the server
+	ccm *CCMApplication
+}
+
+// newCCMFileRoot creates the root folder of a file tree
+func newCCMFileRoot(ccm *CCMApplication, componentId string, source url.Values) *CCMFileNode {
+	return &CCMFileNode{
+		Path:        "/",
+		Folder:      true,
+		ComponentId: componentId,
+		source:      source,
+		ccm:         ccm,
+	}
+}
+
+// Children of this folder (loaded from server on first call)
+func (n *CCMFileNode) Children(ctx context.Context) ([]*CCMFileNode, error) {
+	if !n.Folder {
+		return nil, nil
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if n.children != nil {
+		return n.children, nil
+	}
+
+	query := make(url.Values)
+	for key, value := range n.source {
+		query[key] = value
+	}
+	query.Set("componentItemId", n.ComponentId)
+	if n.ItemId != "" {
+		query.Set("parentItemId", n.ItemId)
+	}
+
+	var values []struct {
+		ItemId   string `json:"itemId"`
+		StateId  string `json:"stateId"`
+		Name     string `json:"name"`
+		ItemType string `json:"itemType"`
+	}
+	err := n.ccm.serviceGet(ctx, ccmFilesystemService+"children?"+query.Encode(), &values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get children of %s: %w", n.Path, err)
+	}
+
+	children := make([]*CCMFileNode, len(values))
+	for i, value := range values {
+		children[i] = &CCMFileNode{
+			Name:        value.Name,
+			Path:        path.Join(n.Path, value.Name),
+			ItemId:      value.ItemId,
+			StateId:     value.StateId,
+			Folder:      value.ItemType == "folder",
+			ComponentId: n.ComponentId,
+			source:      n.source,
+			ccm:         n.ccm,
+		}
+	}
+	n.children = children
+	return children, nil
+}
+
+// Walk calls fn for this node and all nodes below it (depth first).
+// If fn returns fs.SkipDir for a folder its children are skipped.
+func (n *CCMFileNode) Walk(ctx context.Context, fn func(node *CCMFileNode) error) error {
+	err := fn(n)
+	if errors.Is(err, fs.SkipDir) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	children, err := n.Children(ctx)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		err = child.Walk(ctx, fn)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Download content of this file
+func (n *CCMFileNode) Download(ctx context.Context, w io.Writer) error {
+	if n.Folder {
+		return fmt.Errorf("%s is a folder", n.Path)
+	}
+	return n.ccm.DownloadFile(ctx, n.ComponentId, n.ItemId, n.StateId, w)
+}
+
+// Components in this stream or workspace
+func (o *CCMWorkspace) Components(ctx context.Context) ([]*CCMComponent, error) {
+	if o.ccm == nil {
+		return nil, errors.New("workspace is not bound to a CCM application")
+	}
+
+	var values []struct {
+		ItemId string `json:"itemId"`
+	}
+	err := o.ccm.serviceGet(ctx,
+		ccmScmService+"components?workspaceItemId="+url.QueryEscape(o.ItemId),
+		&values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get components of %s: %w", o.Name, err)
+	}
+
+	// an empty filter would list all components of the server
+	if len(values) == 0 {
+		return []*CCMComponent{}, nil
+	}
+
+	ids := make([]interface{}, len(values))
+	for i, value := range values {
+		ids[i] = value.ItemId
+	}
+	// selecting all fields loads the components with a single request
+	components, err := CCMList[*CCMComponent](ctx, o.ccm, CCMComponentFields.ItemId.Filter(ids...).Select(
+		CCMComponentFields.ItemId,
+		CCMComponentFields.Modified,
+		CCMComponentFields.ModifiedBy,
+		CCMComponentFields.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get components of %s: %w", o.Name, err)
+	}
+
+	// keep the order returned by the SCM service
+	order := make(map[string]int, len(values))
+	for i, value := range values {
+		order[value.ItemId] = i
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return order[components[i].ItemId] < order[components[j].ItemId]
+	})
+	return components, nil
+}
+
+// Snapshots of this stream or workspace
+func (o *CCMWorkspace) Snapshots(ctx context.Context) ([]*CCMSnapshot, error) {
+	if o.ccm == nil {
+		return nil, errors.New("workspace is not bound to a CCM application")
+	}
+
+	var values []struct {
+		CCMSnapshot
+		Baselines []struct {
+			CCMBaseline
+			Component struct {
+				ItemId string `json:"itemId"`
+			} `json:"component"`
+		} `json:"baselines"`
+	}
+	err := o.ccm.serviceGet(ctx,
+		ccmScmService+"snapshots?workspaceItemId="+url.QueryEscape(o.ItemId),
+		&values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshots of %s: %w", o.Name, err)
+	}
+
+	snapshots := make([]*CCMSnapshot, len(values))
+	for i := range values {
+		snapshot := values[i].CCMSnapshot
+		for j := range values[i].Baselines {
+			baseline := &values[i].Baselines[j].CCMBaseline
+			baseline.ComponentId = values[i].Baselines[j].Component.ItemId
+			baseline.ccm = o.ccm
+			snapshot.Baselines = append(snapshot.Baselines, baseline)
+		}
+		snapshots[i] = &snapshot
+	}
+	return snapshots, nil
+}
+
+// FileTree of the given component in this stream or workspace
+func (o *CCMWorkspace) FileTree(component *CCMComponent) *CCMFileNode {
+	return newCCMFileRoot(o.ccm, component.ItemId, url.Values{"workspaceItemId": {o.ItemId}})
+}
+
+// Baselines of this component
+func (o *CCMComponent) Baselines(ctx context.Context) ([]*CCMBaseline, error) {
+	if o.ccm == nil {
+		return nil, errors.New("component is not bound to a CCM application")
+	}
+
+	var baselines []*CCMBaseline
+	err := o.ccm.serviceGet(ctx,
+		ccmScmService+"baselines?componentItemId="+url.QueryEscape(o.ItemId),
+		&baselines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get baselines of %s: %w", o.Name, err)
+	}
+
+	for _, baseline := range baselines {
+		baseline.ComponentId = o.ItemId
+		baseline.ccm = o.ccm
+	}
+	return baselines, nil
+}
@@ -0,0 +1,343 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ccmTestTreeChildren contains the children of the folders in component _c1
+// (root folder has no item ID)
+var ccmTestTreeChildren = map[string]string{
+	"": `[{"itemId":"_src","stateId":"_s1","name":"src","itemType":"folder"},
+{"itemId":"_readme","stateId":"_s2","name":"README.md","itemType":"file"}]`,
+	"_src": `[{"itemId":"_main","stateId":"_s3","name":"main.go","itemType":"file"},
+{"itemId":"_docs","stateId":"_s4","name":"docs","itemType":"folder"}]`,
+	"_docs": `[{"itemId":"_guide","stateId":"_s5","name":"guide.md","itemType":"file"}]`,
+}
+
+// ccmTestTreeServer returns a CCM application for a server that provides the
+// SCM services of workspace _ws1 with the components _c1 and _c2
+func ccmTestTreeServer(t *testing.T, requests *int32) *CCMApplication {
+	componentId := regexp.MustCompile(`itemId="(_\w+)"`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		query := r.URL.Query()
+
+		var values string
+		switch r.URL.Path {
+		case "/" + ccmScmService + "components":
+			if query.Get("workspaceItemId") == "_ws1" {
+				values = `[{"itemId":"_c2"},{"itemId":"_c1"}]`
+			} else {
+				values = `[]`
+			}
+
+		case "/" + ccmScmService + "snapshots":
+			if query.Get("workspaceItemId") != "_ws1" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			ccmTestServeFile(t, w, "testdata/ccm/snapshots.json")
+			return
+
+		case "/" + ccmScmService + "baselines":
+			if query.Get("componentItemId") != "_c1" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			ccmTestServeFile(t, w, "testdata/ccm/baselines.json")
+			return
+
+		case "/" + ccmFilesystemService + "children":
+			var ok bool
+			values, ok = ccmTestTreeChildren[query.Get("parentItemId")]
+			if !ok || query.Get("componentItemId") != "_c1" ||
+				(query.Get("workspaceItemId") == "") == (query.Get("baselineItemId") == "") {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+		case "/" + ccmFilesystemContentService + "-/_c1":
+			_, _ = fmt.Fprintf(w, "%s@%s", query.Get("itemId"), query.Get("stateId"))
+			return
+
+		case "/ccm/rpt/repository/scm":
+			w.Header().Set("Content-Type", "application/xml")
+			var builder strings.Builder
+			builder.WriteString("<scm>")
+			for _, match := range componentId.FindAllStringSubmatch(query.Get("fields"), -1) {
+				_, _ = fmt.Fprintf(&builder,
+					"<component><itemId>%s</itemId><stateId>%s_s</stateId><name>Component %s</name></component>",
+					match[1], match[1], match[1])
+			}
+			builder.WriteString("</scm>")
+			_, _ = fmt.Fprint(w, builder.String())
+			return
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/json")
+		_, _ = fmt.Fprintf(w, `{"soapenv:Body":{"response":{"returnValue":{"values":%s}}}}`, values)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL+"/", "user", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client.CCM
+}
+
+// ccmTestServeFile writes the content of the given JSON file
+func ccmTestServeFile(t *testing.T, w http.ResponseWriter, name string) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Error(err)
+	}
+	w.Header().Set("Content-Type", "text/json")
+	_, _ = w.Write(data)
+}
+
+// ccmTestWorkspace returns the workspace _ws1 bound to the given application
+func ccmTestWorkspace(ccm *CCMApplication) *CCMWorkspace {
+	workspace := &CCMWorkspace{Name: "Workspace"}
+	workspace.ItemId = "_ws1"
+	workspace.setCCM(ccm)
+	return workspace
+}
+
+func TestCCMWorkspaceComponents(t *testing.T) {
+	var requests int32
+	ccm := ccmTestTreeServer(t, &requests)
+
+	components, err := ccmTestWorkspace(ccm).Components(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, component := range components {
+		names = append(names, component.ItemId+":"+component.Name)
+	}
+	// order of the SCM service is kept
+	if strings.Join(names, ",") != "_c2:Component _c2,_c1:Component _c1" {
+		t.Errorf("unexpected components: %v", names)
+	}
+	// one request for the service and one for the components
+	if requests != 2 {
+		t.Errorf("unexpected number of requests: %d", requests)
+	}
+
+	// no components -> no list of all components
+	atomic.StoreInt32(&requests, 0)
+	empty := ccmTestWorkspace(ccm)
+	empty.ItemId = "_empty"
+	components, err = empty.Components(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(components) != 0 || requests != 1 {
+		t.Errorf("unexpected components %v with %d requests", components, requests)
+	}
+}
+
+func TestCCMWorkspaceSnapshots(t *testing.T) {
+	var requests int32
+	ccm := ccmTestTreeServer(t, &requests)
+
+	snapshots, err := ccmTestWorkspace(ccm).Snapshots(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("unexpected number of snapshots: %d", len(snapshots))
+	}
+
+	snapshot := snapshots[0]
+	if snapshot.ItemId != "_ss1" || snapshot.Name != "Release 1.0" || snapshot.Comment != "First release" ||
+		snapshot.CreationDate == nil || !snapshot.CreationDate.Equal(time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected snapshot: %+v", snapshot)
+	}
+	if len(snapshot.Baselines) != 2 {
+		t.Fatalf("unexpected number of baselines: %d", len(snapshot.Baselines))
+	}
+	for i, expected := range []string{"_b1:3:Parser 1.0:_c1", "_b2:4:Docs 1.0:_c2"} {
+		baseline := snapshot.Baselines[i]
+		value := fmt.Sprintf("%s:%d:%s:%s", baseline.ItemId, baseline.Id, baseline.Name, baseline.ComponentId)
+		if value != expected || baseline.ccm != ccm {
+			t.Errorf("unexpected baseline: %s", value)
+		}
+	}
+	if snapshots[1].ItemId != "_ss2" || len(snapshots[1].Baselines) != 0 {
+		t.Errorf("unexpected snapshot: %+v", snapshots[1])
+	}
+
+	// baselines of snapshots can be browsed
+	children, err := snapshot.Baselines[0].FileTree().Children(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(children) != 2 {
+		t.Errorf("unexpected children: %v", children)
+	}
+}
+
+func TestCCMComponentBaselines(t *testing.T) {
+	var requests int32
+	ccm := ccmTestTreeServer(t, &requests)
+
+	component := &CCMComponent{Name: "Parser"}
+	component.ItemId = "_c1"
+	component.setCCM(ccm)
+
+	baselines, err := component.Baselines(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var values []string
+	for _, baseline := range baselines {
+		values = append(values, fmt.Sprintf("%s:%d:%s:%s:%s",
+			baseline.ItemId, baseline.Id, baseline.Name, baseline.Comment, baseline.ComponentId))
+	}
+	if strings.Join(values, ",") != "_b1:3:Parser 1.0:First release:_c1,_b3:5:Parser 1.1::_c1" {
+		t.Errorf("unexpected baselines: %v", values)
+	}
+
+	component.ItemId = "_unknown"
+	_, err = component.Baselines(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failed to get baselines of Parser") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCCMFileNodeChildren(t *testing.T) {
+	var requests int32
+	ccm := ccmTestTreeServer(t, &requests)
+
+	root := ccmTestWorkspace(ccm).FileTree(&CCMComponent{CCMBaseObject: CCMBaseObject{ItemId: "_c1"}})
+	children, err := root.Children(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var values []string
+	for _, child := range children {
+		values = append(values, fmt.Sprintf("%s:%s:%t", child.Path, child.StateId, child.Folder))
+	}
+	if strings.Join(values, ",") != "/src:_s1:true,/README.md:_s2:false" {
+		t.Errorf("unexpected children: %v", values)
+	}
+
+	// children are only loaded once
+	atomic.StoreInt32(&requests, 0)
+	_, err = root.Children(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 0 {
+		t.Errorf("children loaded again")
+	}
+
+	// files have no children
+	children, err = children[1].Children(context.Background())
+	if err != nil || children != nil {
+		t.Errorf("unexpected children of file: %v (%v)", children, err)
+	}
+
+	var content bytes.Buffer
+	err = root.children[1].Download(context.Background(), &content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content.String() != "_readme@_s2" {
+		t.Errorf("unexpected content: %s", content.String())
+	}
+	err = root.Download(context.Background(), &content)
+	if err == nil || !strings.Contains(err.Error(), "is a folder") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCCMFileNodeWalk(t *testing.T) {
+	var requests int32
+	ccm := ccmTestTreeServer(t, &requests)
+	component := &CCMComponent{CCMBaseObject: CCMBaseObject{ItemId: "_c1"}}
+
+	tests := []struct {
+		name  string
+		skip  string
+		paths string
+	}{
+		{"all", "", "/,/src,/src/main.go,/src/docs,/src/docs/guide.md,/README.md"},
+		{"skip", "/src/docs", "/,/src,/src/main.go,/src/docs,/README.md"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var paths []string
+			err := ccmTestWorkspace(ccm).FileTree(component).Walk(context.Background(), func(node *CCMFileNode) error {
+				paths = append(paths, node.Path)
+				if node.Path == test.skip {
+					return fs.SkipDir
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(paths, ",") != test.paths {
+				t.Errorf("unexpected paths: %v", paths)
+			}
+		})
+	}
+
+	// errors of fn stop the walk
+	stop := errors.New("stop")
+	var count int
+	err := ccmTestWorkspace(ccm).FileTree(component).Walk(context.Background(), func(node *CCMFileNode) error {
+		count++
+		if node.Name == "main.go" {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || count != 3 {
+		t.Errorf("unexpected error %v after %d nodes", err, count)
+	}
+
+	// errors of the server are returned
+	err = newCCMFileRoot(ccm, "_unknown", nil).Walk(context.Background(), func(*CCMFileNode) error {
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "failed to get children of /") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
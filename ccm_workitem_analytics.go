@@ -0,0 +1,332 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// CCMStateGroupClosed is the state group of closed work item states
+const CCMStateGroupClosed = "CLOSED_STATES"
+
+// CCMDuration is a duration exported as seconds
+type CCMDuration time.Duration
+
+// Hours of the duration
+func (d CCMDuration) Hours() float64 {
+	return time.Duration(d).Hours()
+}
+
+func (d CCMDuration) String() string {
+	return time.Duration(d).String()
+}
+
+func (d CCMDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).Seconds())
+}
+
+// CCMWorkItemStats contains the analytics of a single work item
+type CCMWorkItemStats struct {
+	// Id of the work item
+	Id int `json:"id"`
+
+	// Summary of the work item
+	Summary string `json:"summary"`
+
+	// Type name of the work item
+	Type string `json:"type,omitempty"`
+
+	// Created contains the creation date of the work item
+	Created *time.Time `json:"created,omitempty"`
+
+	// Resolved contains the resolution date of the work item (nil if open)
+	Resolved *time.Time `json:"resolved,omitempty"`
+
+	// LeadTime from creation to resolution (zero if not resolved)
+	LeadTime CCMDuration `json:"leadTime"`
+
+	// TimeInState contains the time spent in each state mapped by state name
+	TimeInState map[string]CCMDuration `json:"timeInState"`
+
+	// ReopenCount contains how often the work item left a closed state
+	ReopenCount int `json:"reopenCount"`
+
+	// Iteration the work item is planned for
+	Iteration string `json:"iteration,omitempty"`
+}
+
+// CCMIterationThroughput contains the throughput of a single iteration
+type CCMIterationThroughput struct {
+	// Iteration name
+	Iteration string `json:"iteration"`
+
+	// StartDate of the iteration
+	StartDate *time.Time `json:"startDate,omitempty"`
+
+	// EndDate of the iteration
+	EndDate *time.Time `json:"endDate,omitempty"`
+
+	// Resolved is the number of resolved work items planned for the iteration
+	Resolved int `json:"resolved"`
+
+	// Open is the number of unresolved work items planned for the iteration
+	Open int `json:"open"`
+
+	// MeanLeadTime of the resolved work items
+	MeanLeadTime CCMDuration `json:"meanLeadTime"`
+}
+
+// CCMWorkItemAnalytics contains the analytics of a set of work items
+type CCMWorkItemAnalytics struct {
+	WorkItems  []*CCMWorkItemStats       `json:"workItems"`
+	Throughput []*CCMIterationThroughput `json:"throughput"`
+}
+
+// CCMAnalyzeWorkItems computes time in state, lead time, reopen counts and
+// the throughput per iteration of the given work items. The time of open
+// states is counted until now.
+func CCMAnalyzeWorkItems(ctx context.Context, workItems []*CCMWorkItem, now time.Time) (*CCMWorkItemAnalytics, error) {
+	analytics := &CCMWorkItemAnalytics{
+		WorkItems: make([]*CCMWorkItemStats, len(workItems)),
+	}
+
+	var mutex sync.Mutex
+	iterations := make(map[string]*CCMIterationThroughput)
+	var leadTimes = make(map[string][]time.Duration)
+
+	// handle work items in parallel
+	indexChan := make(chan int, len(workItems))
+	g, gctx := errgroup.WithContext(ctx)
+	worker := 1
+	if len(workItems) > 0 && workItems[0].ccm != nil && workItems[0].ccm.client.Worker > 1 {
+		worker = workItems[0].ccm.client.Worker
+	}
+	for i := 0; i < worker; i++ {
+		g.Go(func() error {
+			for index := range indexChan {
+				if err := gctx.Err(); err != nil {
+					return err
+				}
+
+				workItem := workItems[index]
+				stats, err := ccmAnalyzeWorkItem(gctx, workItem, now)
+				if err != nil {
+					return fmt.Errorf("failed to analyze work item %d: %w", workItem.Id, err)
+				}
+				analytics.WorkItems[index] = stats
+
+				if workItem.Target == nil {
+					continue
+				}
+				err = workItem.Target.Load(gctx)
+				if err != nil {
+					return fmt.Errorf("failed to load iteration of work item %d: %w", workItem.Id, err)
+				}
+				stats.Iteration = workItem.Target.Name
+
+				mutex.Lock()
+				throughput, ok := iterations[workItem.Target.ItemId]
+				if !ok {
+					throughput = &CCMIterationThroughput{
+						Iteration: workItem.Target.Name,
+						StartDate: workItem.Target.StartDate,
+						EndDate:   workItem.Target.EndDate,
+					}
+					iterations[workItem.Target.ItemId] = throughput
+				}
+				if stats.Resolved != nil {
+					throughput.Resolved++
+					leadTimes[workItem.Target.ItemId] = append(
+						leadTimes[workItem.Target.ItemId], time.Duration(stats.LeadTime))
+				} else {
+					throughput.Open++
+				}
+				mutex.Unlock()
+			}
+			return nil
+		})
+	}
+
+	for i := range workItems {
+		indexChan <- i
+	}
+	close(indexChan)
+	err := g.Wait()
+	if err != nil {
+		return nil, err
+	}
+	for i, stats := range analytics.WorkItems {
+		if stats == nil {
+			return nil, fmt.Errorf("work item %d was not analyzed", workItems[i].Id)
+		}
+	}
+
+	// calculate mean lead time of iterations
+	for id, throughput := range iterations {
+		var sum time.Duration
+		for _, leadTime := range leadTimes[id] {
+			sum += leadTime
+		}
+		if len(leadTimes[id]) > 0 {
+			throughput.MeanLeadTime = CCMDuration(sum / time.Duration(len(leadTimes[id])))
+		}
+		analytics.Throughput = append(analytics.Throughput, throughput)
+	}
+
+	// sort iterations by start date
+	sort.SliceStable(analytics.Throughput, func(i, j int) bool {
+		a, b := analytics.Throughput[i], analytics.Throughput[j]
+		if a.StartDate == nil || b.StartDate == nil {
+			return b.StartDate != nil || (a.StartDate == nil && a.Iteration < b.Iteration)
+		}
+		return a.StartDate.Before(*b.StartDate)
+	})
+	return analytics, nil
+}
+
+// ccmAnalyzeWorkItem computes the statistics of a single work item
+func ccmAnalyzeWorkItem(ctx context.Context, workItem *CCMWorkItem, now time.Time) (*CCMWorkItemStats, error) {
+	states, err := workItem.History(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ccmWorkItemStats(workItem, states, now), nil
+}
+
+// ccmWorkItemStats computes the statistics of a work item from its states
+// ordered by modification time
+func ccmWorkItemStats(workItem *CCMWorkItem, states []*CCMWorkItem, now time.Time) *CCMWorkItemStats {
+	stats := &CCMWorkItemStats{
+		Id:          workItem.Id,
+		Summary:     workItem.Summary,
+		Created:     workItem.CreationDate,
+		Resolved:    workItem.ResolutionDate,
+		TimeInState: make(map[string]CCMDuration),
+	}
+	if workItem.Type != nil {
+		stats.Type = workItem.Type.Name
+	}
+	if workItem.CreationDate != nil && workItem.ResolutionDate != nil {
+		stats.LeadTime = CCMDuration(workItem.ResolutionDate.Sub(*workItem.CreationDate))
+	}
+
+	for i, state := range states {
+		if state.State == nil || state.Modified == nil {
+			continue
+		}
+
+		// count transitions from closed to other states
+		if i > 0 && states[i-1].State != nil &&
+			states[i-1].State.Group == CCMStateGroupClosed &&
+			state.State.Group != CCMStateGroupClosed {
+			stats.ReopenCount++
+		}
+
+		// state lasts until next state or until now for open states
+		end := now
+		if i+1 < len(states) {
+			if states[i+1].Modified == nil {
+				continue
+			}
+			end = *states[i+1].Modified
+		} else if state.State.Group == CCMStateGroupClosed {
+			continue
+		}
+
+		stats.TimeInState[state.State.Name] += CCMDuration(end.Sub(*state.Modified))
+	}
+	return stats
+}
+
+// WriteJSON writes the analytics as JSON
+func (a *CCMWorkItemAnalytics) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(a)
+}
+
+// WriteWorkItemsCSV writes the statistics of the work items as CSV
+// (durations are written in hours)
+func (a *CCMWorkItemAnalytics) WriteWorkItemsCSV(w io.Writer) error {
+	// collect all states
+	stateSet := make(map[string]struct{})
+	for _, stats := range a.WorkItems {
+		for state := range stats.TimeInState {
+			stateSet[state] = struct{}{}
+		}
+	}
+	states := make([]string, 0, len(stateSet))
+	for state := range stateSet {
+		states = append(states, state)
+	}
+	sort.Strings(states)
+
+	header := []string{"id", "summary", "type", "iteration", "created", "resolved", "lead time", "reopen count"}
+	for _, state := range states {
+		header = append(header, state)
+	}
+
+	rows := make([][]string, len(a.WorkItems))
+	for i, stats := range a.WorkItems {
+		row := []string{
+			strconv.Itoa(stats.Id),
+			stats.Summary,
+			stats.Type,
+			stats.Iteration,
+			formatCSVTime(stats.Created),
+			formatCSVTime(stats.Resolved),
+			formatCSVHours(stats.LeadTime),
+			strconv.Itoa(stats.ReopenCount),
+		}
+		for _, state := range states {
+			row = append(row, formatCSVHours(stats.TimeInState[state]))
+		}
+		rows[i] = row
+	}
+	return writeCSV(w, header, rows)
+}
+
+// WriteThroughputCSV writes the throughput per iteration as CSV
+// (durations are written in hours)
+func (a *CCMWorkItemAnalytics) WriteThroughputCSV(w io.Writer) error {
+	rows := make([][]string, len(a.Throughput))
+	for i, throughput := range a.Throughput {
+		rows[i] = []string{
+			throughput.Iteration,
+			formatCSVTime(throughput.StartDate),
+			formatCSVTime(throughput.EndDate),
+			strconv.Itoa(throughput.Resolved),
+			strconv.Itoa(throughput.Open),
+			formatCSVHours(throughput.MeanLeadTime),
+		}
+	}
+	return writeCSV(w,
+		[]string{"iteration", "start date", "end date", "resolved", "open", "mean lead time"},
+		rows)
+}
+
+// formatCSVHours returns the duration in hours
+func formatCSVHours(d CCMDuration) string {
+	return strconv.FormatFloat(d.Hours(), 'f', 2, 64)
+}
@@ -0,0 +1,237 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ccmTestState creates a work item state with the given state at the given time
+func ccmTestState(state *CCMState, modified time.Time) *CCMWorkItem {
+	return &CCMWorkItem{
+		CCMBaseObject: CCMBaseObject{Modified: &modified},
+		State:         state,
+	}
+}
+
+func TestCCMWorkItemStats(t *testing.T) {
+	start := time.Date(2022, 3, 1, 8, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time {
+		return start.Add(time.Duration(hours) * time.Hour)
+	}
+
+	stateNew := &CCMState{Name: "New"}
+	stateProgress := &CCMState{Name: "In Progress"}
+	stateDone := &CCMState{Name: "Done", Group: CCMStateGroupClosed}
+
+	tests := []struct {
+		name     string
+		resolved *time.Time
+		states   []*CCMWorkItem
+		now      time.Time
+
+		leadTime    time.Duration
+		timeInState map[string]time.Duration
+		reopenCount int
+	}{
+		{
+			name:        "open",
+			states:      []*CCMWorkItem{ccmTestState(stateNew, at(0))},
+			now:         at(5),
+			timeInState: map[string]time.Duration{"New": 5 * time.Hour},
+		},
+		{
+			name:     "resolved",
+			resolved: timePtr(at(10)),
+			states: []*CCMWorkItem{
+				ccmTestState(stateNew, at(0)),
+				ccmTestState(stateProgress, at(2)),
+				ccmTestState(stateDone, at(10)),
+			},
+			now:      at(100),
+			leadTime: 10 * time.Hour,
+			timeInState: map[string]time.Duration{
+				"New":         2 * time.Hour,
+				"In Progress": 8 * time.Hour,
+			},
+		},
+		{
+			name: "reopened",
+			states: []*CCMWorkItem{
+				ccmTestState(stateNew, at(0)),
+				ccmTestState(stateDone, at(1)),
+				ccmTestState(stateProgress, at(3)),
+				ccmTestState(stateDone, at(4)),
+				ccmTestState(stateNew, at(6)),
+			},
+			now: at(7),
+			timeInState: map[string]time.Duration{
+				"New":         2 * time.Hour,
+				"In Progress": 1 * time.Hour,
+				"Done":        4 * time.Hour,
+			},
+			reopenCount: 2,
+		},
+		{
+			name: "states without state or time are skipped",
+			states: []*CCMWorkItem{
+				ccmTestState(stateNew, at(0)),
+				{State: stateProgress},
+				ccmTestState(nil, at(2)),
+			},
+			now: at(3),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			created := at(0)
+			workItem := &CCMWorkItem{
+				Id:             42,
+				Summary:        "summary",
+				CreationDate:   &created,
+				ResolutionDate: test.resolved,
+			}
+
+			stats := ccmWorkItemStats(workItem, test.states, test.now)
+			if stats.Id != 42 || stats.Summary != "summary" {
+				t.Errorf("unexpected work item info %d %s", stats.Id, stats.Summary)
+			}
+			if time.Duration(stats.LeadTime) != test.leadTime {
+				t.Errorf("got lead time %s, expected %s", stats.LeadTime, test.leadTime)
+			}
+			if stats.ReopenCount != test.reopenCount {
+				t.Errorf("got reopen count %d, expected %d", stats.ReopenCount, test.reopenCount)
+			}
+			if len(stats.TimeInState) != len(test.timeInState) {
+				t.Errorf("got time in state %v, expected %v", stats.TimeInState, test.timeInState)
+			}
+			for state, duration := range test.timeInState {
+				if time.Duration(stats.TimeInState[state]) != duration {
+					t.Errorf("got %s in state %s, expected %s", stats.TimeInState[state], state, duration)
+				}
+			}
+		})
+	}
+}
+
+// timePtr returns a pointer to the given time
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestCCMAnalyzeWorkItemsWithoutWorker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = fmt.Fprint(w, `<workitem><workItem><itemHistory>
+<itemId>_wi</itemId><stateId>_s1</stateId><modified>2022-03-01T08:00:00.000+0000</modified>
+<state><name>New</name><group>OPEN_STATES</group></state>
+</itemHistory></workItem></workitem>`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL+"/", "user", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Worker = 0
+
+	workItem := &CCMWorkItem{
+		CCMBaseObject: CCMBaseObject{ItemId: "_wi", StateId: "_s1", ccm: client.CCM},
+		Id:            1,
+		Summary:       "first",
+	}
+
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	analytics, err := CCMAnalyzeWorkItems(context.Background(), []*CCMWorkItem{workItem}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(analytics.WorkItems) != 1 || analytics.WorkItems[0] == nil {
+		t.Fatalf("work item not analyzed: %v", analytics.WorkItems)
+	}
+	if d := time.Duration(analytics.WorkItems[0].TimeInState["New"]); d != 2*time.Hour {
+		t.Errorf("got %s in state New, expected 2h", d)
+	}
+
+	var buffer bytes.Buffer
+	err = analytics.WriteWorkItemsCSV(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "id,summary,type,iteration,created,resolved,lead time,reopen count,New\n" +
+		"1,first,,,,,0.00,0,2.00\n"
+	if buffer.String() != expected {
+		t.Errorf("got CSV:\n%s\nexpected:\n%s", buffer.String(), expected)
+	}
+}
+
+func TestCCMAnalyzeWorkItemsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = fmt.Fprint(w, `<error>broken</error>`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL+"/", "user", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workItems := make([]*CCMWorkItem, 50)
+	for i := range workItems {
+		workItems[i] = &CCMWorkItem{
+			CCMBaseObject: CCMBaseObject{ItemId: fmt.Sprintf("_wi%d", i), ccm: client.CCM},
+			Id:            i,
+		}
+	}
+
+	_, err = CCMAnalyzeWorkItems(context.Background(), workItems, time.Now())
+	if err == nil || !strings.Contains(err.Error(), "failed to analyze work item") {
+		t.Errorf("expected error of analysis, got %v", err)
+	}
+}
+
+func TestCCMAnalyticsThroughputCSV(t *testing.T) {
+	start := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	analytics := &CCMWorkItemAnalytics{
+		Throughput: []*CCMIterationThroughput{{
+			Iteration:    "Sprint 1",
+			StartDate:    &start,
+			Resolved:     3,
+			Open:         1,
+			MeanLeadTime: CCMDuration(90 * time.Minute),
+		}},
+	}
+
+	var buffer bytes.Buffer
+	err := analytics.WriteThroughputCSV(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "iteration,start date,end date,resolved,open,mean lead time\n" +
+		"Sprint 1,2022-03-01T00:00:00Z,,3,1,1.50\n"
+	if buffer.String() != expected {
+		t.Errorf("got CSV:\n%s\nexpected:\n%s", buffer.String(), expected)
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// CCMCustomValue contains the value of a custom attribute of a CCMWorkItem.
+//
+// The reportable REST API only provides the identifier of a custom attribute
+// (see CCMAttribute) and not its display name, so attributes are identified
+// by Key only.
+type CCMCustomValue struct {
+	// Key of the custom attribute (identifier of the CCMAttribute)
+	Key string
+
+	// Type of the value (e.g. smallStringValue, timestampValue, itemValue)
+	Type string
+
+	// AttributeType declared by the CCMAttribute (empty if not declared)
+	AttributeType string
+
+	// Value of the custom attribute. Depending on Type this is a bool, int,
+	// int64, float64, string, *time.Time, *CCMItem or []*CCMItem.
+	Value interface{}
+}
+
+// CustomValues of all custom attributes of this work item mapped by key
+func (o *CCMWorkItem) CustomValues() map[string]*CCMCustomValue {
+	values := make(map[string]*CCMCustomValue)
+	add := func(key, valueType string, value interface{}) {
+		if _, ok := values[key]; ok {
+			return
+		}
+		values[key] = &CCMCustomValue{
+			Key:   key,
+			Type:  valueType,
+			Value: value,
+		}
+	}
+
+	// typed extensions
+	for _, e := range o.BooleanExtensions {
+		add(e.Key, "booleanValue", e.Value)
+	}
+	for _, e := range o.IntExtensions {
+		add(e.Key, "integerValue", e.Value)
+	}
+	for _, e := range o.LongExtensions {
+		add(e.Key, "longValue", e.Value)
+	}
+	for _, e := range o.StringExtensions {
+		add(e.Key, "smallStringValue", e.Value)
+	}
+	for _, e := range o.MediumStringExtensions {
+		add(e.Key, "mediumStringValue", e.Value)
+	}
+	for _, e := range o.LargeStringExtensions {
+		add(e.Key, "largeStringValue", e.Value)
+	}
+	for _, e := range o.TimestampExtensions {
+		add(e.Key, "timestampValue", e.Value)
+	}
+	for _, e := range o.BigDecimalExtensions {
+		add(e.Key, "decimalValue", e.Value)
+	}
+	for _, e := range o.ItemExtensions {
+		add(e.Key, "itemValue", e.Value)
+	}
+	for _, e := range o.MultiItemExtensions {
+		add(e.Key, "itemList", e.Value)
+	}
+
+	// generic extensions (only used if not already covered by typed ones)
+	for _, e := range o.AllExtensions {
+		add(e.Key, e.Type, e.value())
+	}
+
+	// add declared types of custom attributes
+	for _, attribute := range o.CustomAttributes {
+		if value, ok := values[attribute.Identifier]; ok {
+			value.AttributeType = attribute.AttributeType
+		}
+	}
+	return values
+}
+
+// Attr returns the value of the custom attribute with the given key
+func (o *CCMWorkItem) Attr(key string) (interface{}, bool) {
+	value, ok := o.CustomValues()[key]
+	if !ok {
+		return nil, false
+	}
+	return value.Value, true
+}
+
+// AttrObjects resolves the items referenced by the item or item list custom
+// attribute with the given key
+func (o *CCMWorkItem) AttrObjects(ctx context.Context, key string) ([]CCMObject, error) {
+	value, ok := o.Attr(key)
+	if !ok {
+		return nil, fmt.Errorf("no custom attribute \"%s\"", key)
+	}
+
+	var items []*CCMItem
+	switch v := value.(type) {
+	case *CCMItem:
+		items = []*CCMItem{v}
+	case []*CCMItem:
+		items = v
+	default:
+		return nil, fmt.Errorf("custom attribute \"%s\" is not an item", key)
+	}
+
+	objects := make([]CCMObject, 0, len(items))
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		obj, err := item.Resolve(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve \"%s\": %w", key, err)
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// CCMAttr returns the value of the custom attribute with the given key
+// converted to the given type
+func CCMAttr[T any](workItem *CCMWorkItem, key string) (T, error) {
+	var nul T
+	value, ok := workItem.Attr(key)
+	if !ok {
+		return nul, fmt.Errorf("no custom attribute \"%s\"", key)
+	}
+
+	v, ok := value.(T)
+	if !ok {
+		return nul, fmt.Errorf("custom attribute \"%s\" is of type %T", key, value)
+	}
+	return v, nil
+}
+
+// value of the extension entry based on its type
+func (o *CCMExtensionEntry) value() interface{} {
+	switch o.Type {
+	case "booleanValue":
+		return o.BooleanValue
+	case "integerValue":
+		return o.IntegerValue
+	case "longValue":
+		return o.LongValue
+	case "doubleValue":
+		return o.DoubleValue
+	case "smallStringValue":
+		return o.SmallStringValue
+	case "mediumStringValue":
+		return o.MediumStringValue
+	case "largeStringValue":
+		return o.LargeStringValue
+	case "timestampValue":
+		return o.TimestampValue
+	case "decimalValue":
+		return o.DecimalValue
+	case "itemValue":
+		return o.ItemValue
+	case "itemList":
+		return o.ItemList
+	default:
+		return nil
+	}
+}
+
+// Resolve the object referenced by this item
+func (o *CCMItem) Resolve(ctx context.Context) (CCMObject, error) {
+	if o.ccm == nil {
+		return nil, errors.New("item is not bound to a CCM application")
+	}
+
+	spec, err := ccmObjectSpecByItemType(o.ItemType)
+	if err != nil {
+		return nil, err
+	}
+
+	value := reflect.New(spec.Type)
+	err = o.ccm.get(ctx, spec, value, o.ItemId)
+	if err != nil {
+		return nil, err
+	}
+	return value.Interface().(CCMObject), nil
+}
+
+// ccmObjectSpecByItemType returns the specification of a loadable object with
+// the given item type (full type ID or only the type name)
+func ccmObjectSpecByItemType(itemType string) (*CCMObjectSpec, error) {
+	if itemType == "" {
+		return nil, errors.New("item type missing")
+	}
+
+	for _, spec := range ccmObjectSpecs {
+		if spec.ElementID == "" || spec.TypeID == "" {
+			continue
+		}
+		if spec.TypeID == itemType || strings.HasSuffix(spec.TypeID, "."+itemType) {
+			return spec, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported item type \"%s\"", itemType)
+}
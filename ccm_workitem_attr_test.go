@@ -0,0 +1,271 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ccmTestAttrWorkItem returns a work item with custom attributes in typed
+// and generic extensions
+func ccmTestAttrWorkItem() *CCMWorkItem {
+	due := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	return &CCMWorkItem{
+		StringExtensions: []*CCMStringExtensionEntry{
+			{Key: "text", Value: "typed"},
+		},
+		IntExtensions: []*CCMIntExtensionEntry{
+			{Key: "count", Value: 3},
+		},
+		TimestampExtensions: []*CCMTimestampExtensionEntry{
+			{Key: "due", Value: &due},
+		},
+		ItemExtensions: []*CCMItemExtensionEntry{
+			{Key: "reviewer", Value: &CCMItem{ItemType: "Contributor", ItemId: "_a"}},
+		},
+		MultiItemExtensions: []*CCMMultiItemExtensionEntry{
+			{Key: "reviewers", Value: []*CCMItem{
+				{ItemType: "com.ibm.team.repository.Contributor", ItemId: "_a"},
+				nil,
+				{ItemType: "Contributor", ItemId: "_b"},
+			}},
+		},
+		AllExtensions: []*CCMExtensionEntry{
+			// already covered by the typed extension
+			{Key: "text", Type: "smallStringValue", SmallStringValue: "generic"},
+			{Key: "count", Type: "longValue", LongValue: 5},
+			// only available as generic extension
+			{Key: "ratio", Type: "doubleValue", DoubleValue: 0.5},
+			{Key: "unknown", Type: "otherValue"},
+		},
+		CustomAttributes: []*CCMAttribute{
+			{Identifier: "text", AttributeType: "smallString"},
+			{Identifier: "ratio", AttributeType: "double"},
+			{Identifier: "missing", AttributeType: "integer"},
+		},
+	}
+}
+
+func TestCCMWorkItemCustomValues(t *testing.T) {
+	values := ccmTestAttrWorkItem().CustomValues()
+
+	tests := []struct {
+		key           string
+		valueType     string
+		attributeType string
+		value         string
+	}{
+		{"text", "smallStringValue", "smallString", "typed"},
+		{"count", "integerValue", "", "3"},
+		{"due", "timestampValue", "", "2022-03-01 12:00:00 +0000 UTC"},
+		{"reviewer", "itemValue", "", "&{Contributor _a}"},
+		{"ratio", "doubleValue", "double", "0.5"},
+		{"unknown", "otherValue", "", "<nil>"},
+	}
+
+	if len(values) != len(tests)+1 {
+		t.Errorf("got %d values, expected %d", len(values), len(tests)+1)
+	}
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			value, ok := values[test.key]
+			if !ok {
+				t.Fatal("value missing")
+			}
+			if value.Key != test.key || value.Type != test.valueType || value.AttributeType != test.attributeType {
+				t.Errorf("got %s (%s, %s), expected %s (%s, %s)",
+					value.Key, value.Type, value.AttributeType,
+					test.key, test.valueType, test.attributeType)
+			}
+
+			str := fmt.Sprint(value.Value)
+			if item, ok := value.Value.(*CCMItem); ok {
+				str = fmt.Sprintf("&{%s %s}", item.ItemType, item.ItemId)
+			}
+			if str != test.value {
+				t.Errorf("got value %s, expected %s", str, test.value)
+			}
+		})
+	}
+
+	// declared attributes without value are not added
+	if _, ok := values["missing"]; ok {
+		t.Error("unexpected value of attribute without value")
+	}
+}
+
+func TestCCMAttr(t *testing.T) {
+	workItem := ccmTestAttrWorkItem()
+
+	text, err := CCMAttr[string](workItem, "text")
+	if err != nil || text != "typed" {
+		t.Errorf("got %s (%v), expected typed value", text, err)
+	}
+	count, err := CCMAttr[int](workItem, "count")
+	if err != nil || count != 3 {
+		t.Errorf("got %d (%v), expected typed value", count, err)
+	}
+	due, err := CCMAttr[*time.Time](workItem, "due")
+	if err != nil || due.Day() != 1 {
+		t.Errorf("got %v (%v)", due, err)
+	}
+
+	// the generic extension contains a long but the typed one wins
+	_, err = CCMAttr[int64](workItem, "count")
+	if err == nil || !strings.Contains(err.Error(), "is of type int") {
+		t.Errorf("expected type error, got %v", err)
+	}
+
+	_, err = CCMAttr[string](workItem, "missing")
+	if err == nil || !strings.Contains(err.Error(), "no custom attribute") {
+		t.Errorf("expected error for missing attribute, got %v", err)
+	}
+
+	if _, ok := workItem.Attr("missing"); ok {
+		t.Error("expected no value for missing attribute")
+	}
+}
+
+// ccmTestContributorServer returns a CCM application whose server returns
+// contributors named after the requested item ID
+func ccmTestContributorServer(t *testing.T, requests *int) *CCMApplication {
+	itemId := regexp.MustCompile(`itemId=(_\w+)`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		match := itemId.FindStringSubmatch(r.URL.Query().Get("fields"))
+		if match == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = fmt.Fprintf(w, `<foundation><contributor><itemId>%s</itemId>
+<name>User %s</name></contributor></foundation>`, match[1], match[1])
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL+"/", "user", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client.CCM
+}
+
+func TestCCMWorkItemAttrObjects(t *testing.T) {
+	var requests int
+	ccm := ccmTestContributorServer(t, &requests)
+
+	workItem := ccmTestAttrWorkItem()
+	workItem.ItemExtensions[0].Value.setCCM(ccm)
+	for _, item := range workItem.MultiItemExtensions[0].Value {
+		if item != nil {
+			item.setCCM(ccm)
+		}
+	}
+
+	tests := []struct {
+		key   string
+		names string
+	}{
+		{"reviewer", "User _a"},
+		{"reviewers", "User _a,User _b"},
+	}
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			objects, err := workItem.AttrObjects(context.Background(), test.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var names []string
+			for _, obj := range objects {
+				contributor, ok := obj.(*CCMContributor)
+				if !ok {
+					t.Fatalf("got object of type %T", obj)
+				}
+				names = append(names, contributor.Name)
+			}
+			if strings.Join(names, ",") != test.names {
+				t.Errorf("got %s, expected %s", strings.Join(names, ","), test.names)
+			}
+		})
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 requests, got %d", requests)
+	}
+}
+
+func TestCCMWorkItemAttrObjectsErrors(t *testing.T) {
+	workItem := ccmTestAttrWorkItem()
+
+	tests := []struct {
+		name string
+		key  string
+		err  string
+	}{
+		{"missing attribute", "missing", "no custom attribute"},
+		{"no item", "text", "is not an item"},
+		// items of work items created by hand are not bound to a server
+		{"not bound", "reviewer", "not bound"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := workItem.AttrObjects(context.Background(), test.key)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected error \"%s\", got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestCCMObjectSpecByItemType(t *testing.T) {
+	tests := []struct {
+		itemType string
+		typeId   string
+	}{
+		{"com.ibm.team.repository.Contributor", "com.ibm.team.repository.Contributor"},
+		{"Contributor", "com.ibm.team.repository.Contributor"},
+		{"WorkItem", "com.ibm.team.workitem.WorkItem"},
+		{"", ""},
+		{"Unknown", ""},
+		// only the full type name matches
+		{"tributor", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.itemType, func(t *testing.T) {
+			spec, err := ccmObjectSpecByItemType(test.itemType)
+			if test.typeId == "" {
+				if err == nil {
+					t.Errorf("expected error, got %s", spec.TypeID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if spec.TypeID != test.typeId {
+				t.Errorf("got %s, expected %s", spec.TypeID, test.typeId)
+			}
+		})
+	}
+}
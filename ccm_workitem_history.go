@@ -0,0 +1,267 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// fields of work items that are not part of the change history
+var ccmWorkItemHistorySkipFields = map[string]struct{}{
+	"DayModified": {},
+	"ItemHistory": {},
+}
+
+// CCMWorkItemChange describes the change of a single field between two states
+// of a work item
+type CCMWorkItemChange struct {
+	// Field name of CCMWorkItem or key of the custom attribute
+	Field string
+
+	// Custom is true if Field is the key of a custom attribute
+	Custom bool
+
+	// OldValue of the field before the change
+	OldValue interface{}
+
+	// NewValue of the field after the change
+	NewValue interface{}
+
+	// ModifiedBy contains the contributor who did the change
+	ModifiedBy *CCMContributor
+
+	// Modified contains the time of the change
+	Modified *time.Time
+}
+
+func (c *CCMWorkItemChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s",
+		c.Field,
+		ccmValueKey(reflect.ValueOf(c.OldValue)),
+		ccmValueKey(reflect.ValueOf(c.NewValue)))
+}
+
+// LoadHistory loads all states of this work item into ItemHistory
+func (o *CCMWorkItem) LoadHistory(ctx context.Context) error {
+	if o.ccm == nil {
+		return errors.New("work item is not bound to a CCM application")
+	}
+	spec := o.Spec()
+
+	resp, root, err := o.ccm.client.getEtree(ctx,
+		fmt.Sprintf(
+			"ccm/rpt/repository/%s?fields=%s/%s[itemId=%s]/itemHistory/(%s)",
+			spec.ResourceID, spec.ElementID, spec.ElementID,
+			o.ItemId,
+			strings.Join(spec.getLoadFields(spec.Type), "|")),
+		"application/xml",
+		"failed get history of "+o.ItemId, 0)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != 200 {
+		return ccmResponse2error(root)
+	}
+
+	elements := root.FindElements(spec.ElementID + "/itemHistory")
+	history := make([]*CCMWorkItem, 0, len(elements))
+	for _, element := range elements {
+		var entry *CCMWorkItem
+		err = spec.Load(o.ccm, reflect.ValueOf(&entry), element)
+		if err != nil {
+			return fmt.Errorf("failed to load history of %s: %w", o.ItemId, err)
+		}
+
+		// history entries are complete -> prevent reload of current state
+		entry.init.Do(func() {})
+		history = append(history, entry)
+	}
+
+	o.ItemHistory = history
+	return nil
+}
+
+// History returns all states of this work item ordered by modification time
+func (o *CCMWorkItem) History(ctx context.Context) ([]*CCMWorkItem, error) {
+	err := o.LoadHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]*CCMWorkItem, 0, len(o.ItemHistory)+1)
+	states = append(states, o.ItemHistory...)
+
+	// add current state if not part of the history
+	current := true
+	for _, state := range states {
+		if state.StateId == o.StateId {
+			current = false
+			break
+		}
+	}
+	if current {
+		states = append(states, o)
+	}
+
+	sort.SliceStable(states, func(i, j int) bool {
+		if states[i].Modified == nil || states[j].Modified == nil {
+			return states[j].Modified != nil
+		}
+		return states[i].Modified.Before(*states[j].Modified)
+	})
+	return states, nil
+}
+
+// Changes returns all changes of this work item ordered by modification time
+func (o *CCMWorkItem) Changes(ctx context.Context) ([]*CCMWorkItemChange, error) {
+	states, err := o.History(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*CCMWorkItemChange
+	for i := 1; i < len(states); i++ {
+		changes = append(changes, ccmDiffWorkItems(states[i-1], states[i])...)
+	}
+	return changes, nil
+}
+
+// ccmDiffWorkItems returns the changes from the old state to the new state
+func ccmDiffWorkItems(oldState, newState *CCMWorkItem) []*CCMWorkItemChange {
+	var changes []*CCMWorkItemChange
+
+	// built-in fields
+	oldValue := reflect.ValueOf(oldState).Elem()
+	newValue := reflect.ValueOf(newState).Elem()
+	for i := 0; i < goCCMWorkItemType.NumField(); i++ {
+		field := goCCMWorkItemType.Field(i)
+		if field.Tag.Get("jazz") == "" {
+			continue
+		}
+		if _, ok := ccmWorkItemHistorySkipFields[field.Name]; ok {
+			continue
+		}
+
+		// lists of custom attributes are handled separately
+		if strings.HasSuffix(field.Name, "Extensions") {
+			continue
+		}
+
+		if ccmValueKey(oldValue.Field(i)) != ccmValueKey(newValue.Field(i)) {
+			changes = append(changes, &CCMWorkItemChange{
+				Field:      field.Name,
+				OldValue:   oldValue.Field(i).Interface(),
+				NewValue:   newValue.Field(i).Interface(),
+				ModifiedBy: newState.ModifiedBy,
+				Modified:   newState.Modified,
+			})
+		}
+	}
+
+	// custom attributes
+	oldCustom := oldState.CustomValues()
+	newCustom := newState.CustomValues()
+	keys := make([]string, 0, len(oldCustom)+len(newCustom))
+	for key := range newCustom {
+		keys = append(keys, key)
+	}
+	for key := range oldCustom {
+		if _, ok := newCustom[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		var oldCustomValue, newCustomValue interface{}
+		if v, ok := oldCustom[key]; ok {
+			oldCustomValue = v.Value
+		}
+		if v, ok := newCustom[key]; ok {
+			newCustomValue = v.Value
+		}
+
+		if ccmValueKey(reflect.ValueOf(oldCustomValue)) != ccmValueKey(reflect.ValueOf(newCustomValue)) {
+			changes = append(changes, &CCMWorkItemChange{
+				Field:      key,
+				Custom:     true,
+				OldValue:   oldCustomValue,
+				NewValue:   newCustomValue,
+				ModifiedBy: newState.ModifiedBy,
+				Modified:   newState.Modified,
+			})
+		}
+	}
+	return changes
+}
+
+// ccmValueKey returns a string representation of a field value used to
+// detect changes (CCM objects are represented by their ItemId or fields)
+func ccmValueKey(value reflect.Value) string {
+	if !value.IsValid() {
+		return ""
+	}
+
+	switch value.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if value.IsNil() {
+			return ""
+		}
+		return ccmValueKey(value.Elem())
+
+	case reflect.Slice:
+		keys := make([]string, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			keys[i] = ccmValueKey(value.Index(i))
+		}
+		return strings.Join(keys, ",")
+
+	case reflect.Struct:
+		if t, ok := value.Interface().(time.Time); ok {
+			return t.UTC().Format(time.RFC3339Nano)
+		}
+
+		base := value.FieldByName("CCMBaseObject")
+		if !base.IsValid() {
+			return fmt.Sprint(value.Interface())
+		}
+		if itemId := base.FieldByName("ItemId").String(); itemId != "" {
+			return itemId
+		}
+
+		// objects without item ID are represented by their simple fields
+		var keys []string
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if field.Tag.Get("jazz") == "" {
+				continue
+			}
+			switch field.Type.Kind() {
+			case reflect.String, reflect.Bool, reflect.Int, reflect.Int64, reflect.Float64:
+				keys = append(keys, fmt.Sprint(value.Field(i).Interface()))
+			}
+		}
+		return strings.Join(keys, "|")
+
+	default:
+		return fmt.Sprint(value.Interface())
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCCMDiffWorkItems(t *testing.T) {
+	modified := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	user := &CCMContributor{CCMBaseObject: CCMBaseObject{ItemId: "_user"}}
+
+	tests := []struct {
+		name     string
+		oldState *CCMWorkItem
+		newState *CCMWorkItem
+		changes  []string
+	}{
+		{
+			name:     "no changes",
+			oldState: &CCMWorkItem{Summary: "a"},
+			newState: &CCMWorkItem{Summary: "a"},
+		},
+		{
+			name:     "simple field",
+			oldState: &CCMWorkItem{Summary: "a"},
+			newState: &CCMWorkItem{Summary: "b"},
+			changes:  []string{"Summary: a -> b"},
+		},
+		{
+			name: "state transition",
+			oldState: &CCMWorkItem{
+				State: &CCMState{CCMBaseObject: CCMBaseObject{ItemId: "_new"}, Name: "New"},
+			},
+			newState: &CCMWorkItem{
+				State: &CCMState{CCMBaseObject: CCMBaseObject{ItemId: "_done"}, Name: "Done"},
+			},
+			changes: []string{"State: _new -> _done"},
+		},
+		{
+			name: "same owner loaded differently",
+			oldState: &CCMWorkItem{
+				Owner: &CCMContributor{CCMBaseObject: CCMBaseObject{ItemId: "_user"}},
+			},
+			newState: &CCMWorkItem{
+				Owner: &CCMContributor{CCMBaseObject: CCMBaseObject{ItemId: "_user"}, Name: "User"},
+			},
+		},
+		{
+			name:     "owner assigned",
+			oldState: &CCMWorkItem{},
+			newState: &CCMWorkItem{Owner: user},
+			changes:  []string{"Owner:  -> _user"},
+		},
+		{
+			name: "modification fields are ignored",
+			oldState: &CCMWorkItem{
+				CCMBaseObject: CCMBaseObject{StateId: "_s1"},
+				DayModified:   &modified,
+			},
+			newState: &CCMWorkItem{
+				CCMBaseObject: CCMBaseObject{StateId: "_s2", Modified: &modified},
+			},
+		},
+		{
+			name: "custom attributes",
+			oldState: &CCMWorkItem{
+				StringExtensions: []*CCMStringExtensionEntry{{Key: "risk", Value: "low"}},
+				IntExtensions:    []*CCMIntExtensionEntry{{Key: "points", Value: 3}},
+			},
+			newState: &CCMWorkItem{
+				StringExtensions: []*CCMStringExtensionEntry{{Key: "risk", Value: "high"}},
+				BooleanExtensions: []*CCMBooleanExtensionEntry{
+					{Key: "blocking", Value: true},
+				},
+			},
+			changes: []string{
+				"blocking:  -> true",
+				"points: 3 -> ",
+				"risk: low -> high",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.newState.ModifiedBy = user
+			test.newState.Modified = &modified
+
+			var changes []string
+			for _, change := range ccmDiffWorkItems(test.oldState, test.newState) {
+				if change.ModifiedBy != user || change.Modified != &modified {
+					t.Errorf("change %s has wrong modification info", change)
+				}
+				changes = append(changes, change.String())
+			}
+			if !reflect.DeepEqual(changes, test.changes) {
+				t.Errorf("got changes %q, expected %q", changes, test.changes)
+			}
+		})
+	}
+}
+
+func TestCCMDiffWorkItemsCustom(t *testing.T) {
+	oldState := &CCMWorkItem{
+		StringExtensions: []*CCMStringExtensionEntry{{Key: "risk", Value: "low"}},
+	}
+	newState := &CCMWorkItem{
+		Summary:          "changed",
+		StringExtensions: []*CCMStringExtensionEntry{{Key: "risk", Value: "high"}},
+	}
+
+	changes := ccmDiffWorkItems(oldState, newState)
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d", len(changes))
+	}
+	if changes[0].Field != "Summary" || changes[0].Custom {
+		t.Errorf("expected built-in change of Summary, got %+v", changes[0])
+	}
+	if changes[1].Field != "risk" || !changes[1].Custom ||
+		changes[1].OldValue != "low" || changes[1].NewValue != "high" {
+		t.Errorf("expected custom change of risk, got %+v", changes[1])
+	}
+}
+
+func TestCCMValueKey(t *testing.T) {
+	date := time.Date(2022, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name  string
+		value interface{}
+		key   string
+	}{
+		{"nil", nil, ""},
+		{"nil pointer", (*CCMContributor)(nil), ""},
+		{"string", "text", "text"},
+		{"int", 42, "42"},
+		{"time in UTC", &date, "2022-03-01T11:00:00Z"},
+		{"object by item ID", &CCMContributor{
+			CCMBaseObject: CCMBaseObject{ItemId: "_user"},
+			Name:          "User",
+		}, "_user"},
+		{"object without item ID", &CCMStringExtensionEntry{Key: "risk", Value: "low"}, "risk|low"},
+		{"list", []*CCMContributor{
+			{CCMBaseObject: CCMBaseObject{ItemId: "_a"}},
+			{CCMBaseObject: CCMBaseObject{ItemId: "_b"}},
+		}, "_a,_b"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key := ccmValueKey(reflect.ValueOf(test.value))
+			if key != test.key {
+				t.Errorf("got key \"%s\", expected \"%s\"", key, test.key)
+			}
+		})
+	}
+}
@@ -174,11 +174,50 @@ func (c *Client) get(ctx context.Context, url, contentType string, noGc bool) (*
 
 // put sends GET request to server
 func (c *Client) put(ctx context.Context, url, contentType string, reader io.Reader) (*http.Response, error) {
+	return c.putIfMatch(ctx, url, contentType, "", reader)
+}
+
+// putIfMatch sends PUT request to server that only succeeds if the object
+// on the server has the given ETag (unconditional if empty)
+func (c *Client) putIfMatch(ctx context.Context, url, contentType, etag string, reader io.Reader) (*http.Response, error) {
 	request, err := http.NewRequestWithContext(ctx, "PUT", c.buildUrl(url), reader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create put request: %w", err)
 	}
 	request.Header.Set("Content-type", contentType)
+	if etag != "" {
+		request.Header.Set("If-Match", etag)
+	}
+
+	return c.sendRequest(request, false)
+}
+
+// post sends POST request to server
+func (c *Client) post(ctx context.Context, url, contentType string, reader io.Reader) (*http.Response, error) {
+	return c.postAccept(ctx, url, contentType, "", reader)
+}
+
+// postAccept sends POST request to server that expects a response of the
+// given content type (same as the request if empty)
+func (c *Client) postAccept(ctx context.Context, url, contentType, accept string, reader io.Reader) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, "POST", c.buildUrl(url), reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create post request: %w", err)
+	}
+	request.Header.Set("Content-type", contentType)
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+
+	return c.sendRequest(request, false)
+}
+
+// delete sends DELETE request to server
+func (c *Client) delete(ctx context.Context, url string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, "DELETE", c.buildUrl(url), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create delete request: %w", err)
+	}
 
 	return c.sendRequest(request, false)
 }
@@ -227,6 +266,9 @@ func (c *Client) sendRequest(request *http.Request, noGc bool) (*http.Response,
 		}
 
 		// resend original request
+		if err := rewindBody(request); err != nil {
+			return nil, err
+		}
 		return c.sendRawRequest(request, true, noGc)
 	}
 
@@ -239,6 +281,9 @@ func (c *Client) sendRequest(request *http.Request, noGc bool) (*http.Response,
 		c.basicAuth = true
 
 		// resend original request
+		if err := rewindBody(request); err != nil {
+			return nil, err
+		}
 		response, err = c.sendRawRequest(request, true, noGc)
 		if err != nil {
 			return nil, err
@@ -260,6 +305,25 @@ func (c *Client) sendRequest(request *http.Request, noGc bool) (*http.Response,
 	return response, nil
 }
 
+// rewindBody restores the body of a request that was already sent. Only
+// bodies with GetBody (set by http.NewRequest for buffers and readers of
+// strings or bytes) can be sent again.
+func rewindBody(request *http.Request) error {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil
+	}
+	if request.GetBody == nil {
+		return errors.New("request body can not be sent again after authentication")
+	}
+
+	body, err := request.GetBody()
+	if err != nil {
+		return fmt.Errorf("failed to rewind request body: %w", err)
+	}
+	request.Body = body
+	return nil
+}
+
 // sendRawRequest to server
 func (c *Client) sendRawRequest(request *http.Request, log, noGc bool) (*http.Response, error) {
 	if log {
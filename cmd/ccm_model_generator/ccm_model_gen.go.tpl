@@ -57,6 +57,24 @@ func (o *{{ .Name }}) Spec() *CCMObjectSpec {
 		Type:       go{{ .Name }}Type,
 	}
 }
+
+// {{ .Name }}Fields contains the field descriptors of {{ .Name }}
+{{- $name := .Name }}
+var {{ .Name }}Fields = struct {
+{{- range .BaseFields}}
+	{{ . }} CCMField
+{{- end}}
+{{- range .Fields}}
+	{{ .GoName }} CCMField
+{{- end}}
+}{
+{{- range .BaseFields}}
+	{{ . }}: ccmField(go{{ $name }}Type, "{{ . }}"),
+{{- end}}
+{{- range .Fields}}
+	{{ .GoName }}: ccmField(go{{ $name }}Type, "{{ .GoName }}"),
+{{- end}}
+}
 {{ if .IsLoadable }}
 // Load {{ .Name }} object
 func (o *{{ .Name }}) Load(ctx context.Context) (err error) {
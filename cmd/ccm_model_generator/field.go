@@ -42,6 +42,15 @@ func (f Field) GoName() string {
 
 // IsCCMType returns true if this is a CCM field
 func (f Field) IsCCMType() bool {
+	// check if the type is an object
+	if _, ok := modelTypeRef[f.typeID()]; ok {
+		return true
+	}
+	return false
+}
+
+// typeID returns the type of the field without the note about list entries
+func (f Field) typeID() string {
 	// remove note about list entries from type
 	t := listFieldRegEx.ReplaceAllString(f.Type, "")
 
@@ -49,16 +58,26 @@ func (f Field) IsCCMType() bool {
 	if fixedType, ok := invalidTypes[t]; ok {
 		t = fixedType
 	}
-
-	// check if the type is an object
-	if _, ok := modelTypeRef[t]; ok {
-		return true
-	}
-	return false
+	return t
 }
 
 // GoType returns the type for the Go struct field
 func (f Field) GoType() string {
+	t, ok := f.goType()
+	if !ok {
+		panic("unknown type: " + f.Type)
+	}
+	return t
+}
+
+// isKnownType returns true if the type of the field can be mapped to a Go type
+func (f Field) isKnownType() bool {
+	_, ok := f.goType()
+	return ok
+}
+
+// goType returns the type for the Go struct field or false if the type is unknown
+func (f Field) goType() (string, bool) {
 	// remove note about list entries from type
 	t := listFieldRegEx.ReplaceAllString(f.Type, "")
 
@@ -75,25 +94,25 @@ func (f Field) GoType() string {
 
 	// check if the type is an object
 	if model, ok := modelTypeRef[t]; ok {
-		return prefix + "*" + model.Name()
+		return prefix + "*" + model.Name(), true
 	}
 
 	// handle basic type
 	switch t {
 	case "xs:string":
-		return prefix + "string"
+		return prefix + "string", true
 	case "xs:time", "xs:date":
-		return prefix + "*time.Time"
+		return prefix + "*time.Time", true
 	case "xs:boolean":
-		return prefix + "bool"
+		return prefix + "bool", true
 	case "xs:integer":
-		return prefix + "int"
+		return prefix + "int", true
 	case "xs:double", "xs:decimal":
-		return prefix + "float64"
+		return prefix + "float64", true
 	case "xs:long":
-		return prefix + "int64"
+		return prefix + "int64", true
 	default:
-		panic("unknown type: " + t)
+		return "", false
 	}
 }
 
@@ -27,7 +27,7 @@ var invalidTypes = map[string]string{
 	"com.ibm.workitem.Deliverable":      "com.ibm.team.workitem.Deliverable",
 }
 
-// fields to ignore on objects
+// fields to ignore on documented objects (models of schemas are not affected)
 var skipFields = map[string]map[string]struct{}{
 	"com.ibm.team.workitem.Approval": {
 		"approvalDescriptor": {}, // causes infinite recursion
@@ -0,0 +1,93 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"testing"
+)
+
+var updateFlag = flag.Bool("update", false, "update the golden files")
+
+// compareGolden compares the generated code with the golden file
+// (or updates the golden file if -update is set)
+func compareGolden(t *testing.T, golden string, code []byte) {
+	t.Helper()
+
+	if *updateFlag {
+		err := os.WriteFile(golden, code, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(code, expected) {
+		t.Errorf("generated code differs from %s in line %d (run with -update to regenerate)",
+			golden, firstDifferentLine(code, expected))
+	}
+}
+
+// firstDifferentLine returns the number of the first line that differs
+func firstDifferentLine(a, b []byte) int {
+	linesA := bytes.Split(a, []byte("\n"))
+	linesB := bytes.Split(b, []byte("\n"))
+	for i := 0; i < len(linesA) && i < len(linesB); i++ {
+		if !bytes.Equal(linesA[i], linesB[i]) {
+			return i + 1
+		}
+	}
+	if len(linesA) < len(linesB) {
+		return len(linesA) + 1
+	}
+	return len(linesB) + 1
+}
+
+func TestGenerateModels(t *testing.T) {
+	code, err := generateModels("testdata/documentation.html", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareGolden(t, "testdata/documentation.go.golden", code)
+}
+
+func TestGenerateModelsDeterministic(t *testing.T) {
+	first, err := generateModels("testdata/documentation.html", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		code, err := generateModels("testdata/documentation.html", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(first, code) {
+			t.Fatalf("output of run %d differs in line %d", i+2, firstDifferentLine(first, code))
+		}
+	}
+}
+
+func TestGenerateModelsMissingSource(t *testing.T) {
+	_, err := generateModels("testdata/missing.html", nil)
+	if err == nil {
+		t.Error("expected error for missing documentation")
+	}
+}
@@ -19,6 +19,10 @@ import (
 	"strings"
 )
 
+// descriptorBaseFields are the fields of CCMBaseObject that get a field
+// descriptor in every model
+var descriptorBaseFields = []string{"ItemId", "Modified", "ModifiedBy"}
+
 // Model of an RTC CCM object
 type Model struct {
 	// Description of object
@@ -63,3 +67,22 @@ func (m Model) CCMFields() []Field {
 	}
 	return fields
 }
+
+// BaseFields returns the names of CCMBaseObject fields with a field
+// descriptor (skips fields overwritten by this model)
+func (m Model) BaseFields() []string {
+	fields := make([]string, 0, len(descriptorBaseFields))
+	for _, name := range descriptorBaseFields {
+		overwritten := false
+		for _, field := range m.Fields {
+			if field.GoName() == name {
+				overwritten = true
+				break
+			}
+		}
+		if !overwritten {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
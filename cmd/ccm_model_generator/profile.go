@@ -0,0 +1,176 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/beevik/etree"
+)
+
+// Profile of custom work item attributes of a server
+type Profile struct {
+	// Package of the generated file
+	Package string
+
+	// Attributes of the profile sorted by identifier
+	Attributes []ProfileAttribute
+}
+
+// Prefix for types of the jazz package
+func (p Profile) Prefix() string {
+	if p.Package == "jazz" {
+		return ""
+	}
+	return "jazz."
+}
+
+// HasTime returns true if an attribute requires the time package
+func (p Profile) HasTime() bool {
+	for _, attribute := range p.Attributes {
+		if attribute.GoType(p.Prefix()) == "*time.Time" {
+			return true
+		}
+	}
+	return false
+}
+
+// ProfileAttribute is a custom attribute of the profile
+type ProfileAttribute struct {
+	// Identifier of the custom attribute (key of the extension entry)
+	Identifier string
+
+	// AttributeType of the custom attribute
+	AttributeType string
+
+	// GoName of the struct field
+	GoName string
+}
+
+// GoType returns the type for the Go struct field (types of the jazz package
+// are prefixed with the given prefix)
+func (a ProfileAttribute) GoType(prefix string) string {
+	switch a.AttributeType {
+	case "boolean":
+		return "bool"
+	case "integer":
+		return "int"
+	case "long":
+		return "int64"
+	case "decimal":
+		return "float64"
+	case "smallString", "mediumString", "largeString", "string",
+		"html", "mediumHtml", "largeHtml", "wiki":
+		return "string"
+	case "timestamp":
+		return "*time.Time"
+	case "contributor", "category", "iteration", "deliverable", "workItem",
+		"projectArea", "teamArea", "processArea", "item":
+		return "*" + prefix + "CCMItem"
+	}
+
+	if strings.HasSuffix(a.AttributeType, "List") {
+		return "[]*" + prefix + "CCMItem"
+	}
+	return "interface{}"
+}
+
+// loadProfile of custom attributes from a reportable REST response that
+// contains custom attribute declarations (e.g. the response of
+// ccm/rpt/repository/workitem?fields=workitem/workItem/customAttributes/(identifier|attributeType|builtIn))
+func loadProfile(source, pkg string) (*Profile, error) {
+	reader, err := openSource(source)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	doc := etree.NewDocument()
+	_, err = doc.ReadFrom(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse profile %s: %w", source, err)
+	}
+
+	// collect unique attributes
+	attributes := make(map[string]ProfileAttribute)
+	for _, element := range doc.FindElements("//customAttributes") {
+		identifier := element.FindElement("identifier")
+		attributeType := element.FindElement("attributeType")
+		if identifier == nil || attributeType == nil {
+			continue
+		}
+		if builtIn := element.FindElement("builtIn"); builtIn != nil && builtIn.Text() == "true" {
+			continue
+		}
+
+		attributes[identifier.Text()] = ProfileAttribute{
+			Identifier:    identifier.Text(),
+			AttributeType: attributeType.Text(),
+		}
+	}
+
+	profile := &Profile{
+		Package:    pkg,
+		Attributes: make([]ProfileAttribute, 0, len(attributes)),
+	}
+	for _, attribute := range attributes {
+		profile.Attributes = append(profile.Attributes, attribute)
+	}
+	sort.Slice(profile.Attributes, func(i, j int) bool {
+		return profile.Attributes[i].Identifier < profile.Attributes[j].Identifier
+	})
+
+	// create unique Go names
+	names := make(map[string]int)
+	for i, attribute := range profile.Attributes {
+		name := profileGoName(attribute.Identifier)
+		names[name]++
+		if names[name] > 1 {
+			name += strconv.Itoa(names[name])
+		}
+		profile.Attributes[i].GoName = name
+	}
+	return profile, nil
+}
+
+// profileGoName creates a Go name from the identifier of a custom attribute
+// (e.g. com.example.risk_level -> RiskLevel)
+func profileGoName(identifier string) string {
+	name := identifier[strings.LastIndex(identifier, ".")+1:]
+
+	var builder strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		builder.WriteRune(r)
+	}
+
+	goName := builder.String()
+	if goName == "" || unicode.IsDigit(rune(goName[0])) {
+		goName = "Attr" + goName
+	}
+	return goName
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGenerateProfile(t *testing.T) {
+	code, err := generateProfile("testdata/profile.xml", "custom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareGolden(t, "testdata/ccm_custom_attr_gen.go.golden", code)
+}
+
+func TestGenerateProfileJazzPackage(t *testing.T) {
+	code, err := generateProfile("testdata/profile.xml", "jazz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(code, []byte("jazz.")) || bytes.Contains(code, []byte("go-jazz")) {
+		t.Errorf("types of the jazz package must not be prefixed:\n%s", code)
+	}
+}
+
+func TestProfileGoName(t *testing.T) {
+	tests := map[string]string{
+		"com.example.risk_level": "RiskLevel",
+		"com.example.risk-level": "RiskLevel",
+		"riskLevel":              "RiskLevel",
+		"com.example.1st":        "Attr1st",
+		"com.example.___":        "Attr",
+	}
+	for identifier, expected := range tests {
+		if name := profileGoName(identifier); name != expected {
+			t.Errorf("got name %s for %s, expected %s", name, identifier, expected)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// fields provided by CCMBaseObject (not added to models)
+var baseFields = map[string]struct{}{
+	"itemId":        {},
+	"uniqueId":      {},
+	"stateId":       {},
+	"contextId":     {},
+	"modified":      {},
+	"archived":      {},
+	"reportableUrl": {},
+	"modifiedBy":    {},
+}
+
+// schemaSources maps resource IDs to XML schemas (file or URL)
+type schemaSources map[string]string
+
+func (s schemaSources) String() string {
+	resources := make([]string, 0, len(s))
+	for resource, source := range s {
+		resources = append(resources, resource+"="+source)
+	}
+	sort.Strings(resources)
+	return strings.Join(resources, ",")
+}
+
+func (s schemaSources) Set(value string) error {
+	resource, source, ok := strings.Cut(value, "=")
+	if !ok || resource == "" || source == "" {
+		return fmt.Errorf("invalid schema \"%s\" (expected resource=source)", value)
+	}
+	s[resource] = source
+	return nil
+}
+
+// Schema of a resource of the reportable REST API
+// (e.g. ccm/rpt/repository/workitem?metadata=schema)
+type Schema struct {
+	Elements     []SchemaRootElement `xml:"element"`
+	ComplexTypes []SchemaComplexType `xml:"complexType"`
+}
+
+// SchemaRootElement is the root element of a resource that lists the
+// elements that can be requested directly
+type SchemaRootElement struct {
+	Name     string          `xml:"name,attr"`
+	Type     string          `xml:"type,attr"`
+	Elements []SchemaElement `xml:"complexType>sequence>element"`
+}
+
+// SchemaComplexType is a complex type definition of the schema
+type SchemaComplexType struct {
+	Name              string          `xml:"name,attr"`
+	Elements          []SchemaElement `xml:"sequence>element"`
+	ExtensionElements []SchemaElement `xml:"complexContent>extension>sequence>element"`
+}
+
+// SchemaElement is an element inside a complex type
+type SchemaElement struct {
+	Name      string `xml:"name,attr"`
+	Type      string `xml:"type,attr"`
+	MaxOccurs string `xml:"maxOccurs,attr"`
+}
+
+// loadSchema from the given file or URL
+func loadSchema(source string) (*Schema, error) {
+	reader, err := openSource(source)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var schema Schema
+	err = xml.NewDecoder(reader).Decode(&schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema %s: %w", source, err)
+	}
+	return &schema, nil
+}
+
+// schemaTypeName removes the namespace prefix of non XML schema types
+func schemaTypeName(name string) string {
+	if strings.HasPrefix(name, "xs:") {
+		return name
+	}
+	return name[strings.Index(name, ":")+1:]
+}
+
+// fieldType returns the type of the element in the notation of the documentation
+func (e SchemaElement) fieldType() string {
+	t := schemaTypeName(e.Type)
+	if e.MaxOccurs != "" && e.MaxOccurs != "1" {
+		t += ", maxOccurs: " + e.MaxOccurs
+	}
+	return t
+}
+
+// elementIDs returns the element IDs of the root elements mapped by type ID
+func (s *Schema) elementIDs() map[string]string {
+	complexTypes := make(map[string]SchemaComplexType, len(s.ComplexTypes))
+	for _, complexType := range s.ComplexTypes {
+		complexTypes[schemaTypeName(complexType.Name)] = complexType
+	}
+
+	elementIDs := make(map[string]string)
+	for _, root := range s.Elements {
+		elements := root.Elements
+		if root.Type != "" {
+			elements = complexTypes[schemaTypeName(root.Type)].Elements
+		}
+		for _, element := range elements {
+			elementIDs[schemaTypeName(element.Type)] = element.Name
+		}
+	}
+	return elementIDs
+}
+
+// models of the given resource defined by the schema. Descriptions are taken
+// from the documented models with the same type ID.
+func (s *Schema) models(resource string, documented map[string]Model) []Model {
+	elementIDs := s.elementIDs()
+
+	models := make([]Model, 0, len(s.ComplexTypes))
+	for _, complexType := range s.ComplexTypes {
+		typeId := schemaTypeName(complexType.Name)
+		doc := documented[typeId]
+
+		fieldDescriptions := make(map[string][]string, len(doc.Fields))
+		for _, field := range doc.Fields {
+			fieldDescriptions[field.Name] = field.Description
+		}
+
+		model := Model{
+			LinkRef:     doc.LinkRef,
+			Description: doc.Description,
+			ResourceID:  resource,
+			ElementID:   elementIDs[typeId],
+			TypeID:      typeId,
+		}
+
+		elements := make([]SchemaElement, 0, len(complexType.ExtensionElements)+len(complexType.Elements))
+		elements = append(elements, complexType.ExtensionElements...)
+		elements = append(elements, complexType.Elements...)
+		for _, element := range elements {
+			if _, ok := baseFields[element.Name]; ok {
+				continue
+			}
+
+			model.Fields = append(model.Fields, Field{
+				Name:        element.Name,
+				Type:        element.fieldType(),
+				Description: fieldDescriptions[element.Name],
+			})
+		}
+		models = append(models, model)
+	}
+	return models
+}
+
+// removeEmbeddedCycles removes fields of the models of the given resources
+// that would embed a model into itself. Models without element ID are loaded
+// together with the object containing them, so such a cycle can not be
+// loaded. Fields are removed in the order of the models (modelTypeRef must
+// be initialized).
+func removeEmbeddedCycles(models []Model, resources schemaSources) {
+	// fields are removed in place, so the models are accessed by index
+	index := make(map[string]int, len(models))
+	for i, model := range models {
+		if _, ok := resources[model.ResourceID]; ok && model.TypeID != "" {
+			index[model.TypeID] = i
+		}
+	}
+
+	visited := make(map[string]bool, len(index))
+	active := make(map[string]bool)
+	var visit func(typeId string)
+	visit = func(typeId string) {
+		visited[typeId] = true
+		active[typeId] = true
+
+		model := &models[index[typeId]]
+		fields := make([]Field, 0, len(model.Fields))
+		for _, field := range model.Fields {
+			target := field.typeID()
+			if _, ok := index[target]; !ok || modelTypeRef[target].IsLoadable() {
+				fields = append(fields, field)
+				continue
+			}
+
+			if active[target] {
+				log.Printf("skip field %s of %s that embeds %s in itself", field.Name, typeId, target)
+				continue
+			}
+			if !visited[target] {
+				visit(target)
+			}
+			fields = append(fields, field)
+		}
+		model.Fields = fields
+
+		active[typeId] = false
+	}
+
+	for _, model := range models {
+		if _, ok := index[model.TypeID]; ok && !visited[model.TypeID] && !model.IsLoadable() {
+			visit(model.TypeID)
+		}
+	}
+}
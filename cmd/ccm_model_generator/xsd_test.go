@@ -0,0 +1,150 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// fieldNames of the given model
+func fieldNames(model Model) []string {
+	names := make([]string, len(model.Fields))
+	for i, field := range model.Fields {
+		names[i] = field.Name
+	}
+	return names
+}
+
+func TestSchemaModels(t *testing.T) {
+	schema, err := loadSchema("testdata/workitem.xsd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	documented := map[string]Model{
+		"com.ibm.team.workitem.WorkItem": {
+			LinkRef:     "workItem",
+			Description: []string{"This element represents a Work Item."},
+			Fields: []Field{
+				{Name: "summary", Description: []string{"The summary"}},
+			},
+		},
+	}
+
+	models := schema.models("workitem", documented)
+	if len(models) != 3 {
+		t.Fatalf("expected 3 models, got %d", len(models))
+	}
+
+	workItem := models[0]
+	if workItem.TypeID != "com.ibm.team.workitem.WorkItem" || workItem.ElementID != "workItem" ||
+		workItem.ResourceID != "workitem" || workItem.LinkRef != "workItem" {
+		t.Errorf("unexpected work item model %s (%s)", workItem, workItem.LinkRef)
+	}
+	if names := fieldNames(workItem); !reflect.DeepEqual(names,
+		[]string{"id", "summary", "owner", "approvals", "attachment"}) {
+		t.Errorf("unexpected fields of work item %v", names)
+	}
+	if !reflect.DeepEqual(workItem.Fields[1].Description, []string{"The summary"}) {
+		t.Errorf("description of documentation not used: %v", workItem.Fields[1].Description)
+	}
+	if workItem.Fields[3].Type != "com.ibm.team.workitem.Approval, maxOccurs: unbounded" {
+		t.Errorf("unexpected type of list field: %s", workItem.Fields[3].Type)
+	}
+
+	// fields are not filtered by the fixes of the documentation
+	approval := models[1]
+	if approval.ElementID != "" {
+		t.Errorf("embedded type must not have an element ID: %s", approval.ElementID)
+	}
+	if names := fieldNames(approval); !reflect.DeepEqual(names,
+		[]string{"stateIdentifier", "approver", "approvalDescriptor"}) {
+		t.Errorf("unexpected fields of approval %v", names)
+	}
+}
+
+func TestRemoveEmbeddedCycles(t *testing.T) {
+	schema, err := loadSchema("testdata/workitem.xsd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	models := schema.models("workitem", nil)
+
+	modelTypeRef = make(map[string]Model)
+	for _, model := range models {
+		modelTypeRef[model.TypeID] = model
+	}
+	removeEmbeddedCycles(models, schemaSources{"workitem": "testdata/workitem.xsd"})
+
+	expected := map[string][]string{
+		"com.ibm.team.workitem.WorkItem":           {"id", "summary", "owner", "approvals", "attachment"},
+		"com.ibm.team.workitem.Approval":           {"stateIdentifier", "approver", "approvalDescriptor"},
+		"com.ibm.team.workitem.ApprovalDescriptor": {"name"},
+	}
+	for _, model := range models {
+		if names := fieldNames(model); !reflect.DeepEqual(names, expected[model.TypeID]) {
+			t.Errorf("unexpected fields of %s: %v", model.TypeID, names)
+		}
+	}
+}
+
+func TestGenerateModelsWithSchema(t *testing.T) {
+	code, err := generateModels("testdata/documentation.html",
+		schemaSources{"workitem": "testdata/workitem.xsd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pattern := range []string{
+		// models of the schema with descriptions of the documentation
+		`(?m)^// CCMWorkItem \(see https://jazz.net/wiki/bin/view/Main/ReportsRESTAPI#workItem_type_com_ibm_team_worki\)$`,
+		`(?m)^\tSummary string ` + "`" + `jazz:"summary"` + "`$",
+		`(?m)^\tApprovals \[\]\*CCMApproval ` + "`" + `jazz:"approvals"` + "`$",
+		`(?m)^\tOwner \*CCMContributor ` + "`" + `jazz:"owner"` + "`$",
+		`(?m)^\tApprovalDescriptor \*CCMApprovalDescriptor ` + "`" + `jazz:"approvalDescriptor"` + "`$",
+
+		// models of other resources are taken from the documentation
+		`(?m)^type CCMProjectArea struct \{$`,
+	} {
+		if !regexp.MustCompile(pattern).Match(code) {
+			t.Errorf("generated code does not match %s", pattern)
+		}
+	}
+
+	for _, pattern := range []string{
+		// unsupported types and cycles are removed
+		`jazz:"attachment"`,
+		`(?s)type CCMApprovalDescriptor struct \{[^}]*jazz:"approvals"`,
+
+		// documented models of the resource are replaced
+		`jazz:"resolutionDate"`,
+	} {
+		if regexp.MustCompile(pattern).Match(code) {
+			t.Errorf("generated code must not match %s", pattern)
+		}
+	}
+
+	// generation without schema is not affected
+	plain, err := generateModels("testdata/documentation.html", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(plain, code) {
+		t.Error("schema not used for generation")
+	}
+}
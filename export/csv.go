@@ -0,0 +1,89 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// csvWriter writes objects as CSV rows
+type csvWriter struct {
+	*exporter
+	writer        *csv.Writer
+	headerWritten bool
+	closed        bool
+}
+
+// NewCSVWriter creates a writer that writes one CSV row per object.
+// List values are joined with the list separator.
+func NewCSVWriter(w io.Writer, options Options) Writer {
+	return &csvWriter{
+		exporter: newExporter(options),
+		writer:   csv.NewWriter(w),
+	}
+}
+
+// writeHeader if not already written
+func (w *csvWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+
+	header := make([]string, len(w.columns))
+	for i, column := range w.columns {
+		header[i] = column.Name
+	}
+	return w.writer.Write(header)
+}
+
+func (w *csvWriter) Write(obj interface{}) error {
+	if w.closed {
+		return errClosed
+	}
+
+	values, err := w.values(obj)
+	if err != nil {
+		return err
+	}
+	err = w.writeHeader()
+	if err != nil {
+		return err
+	}
+
+	row := make([]string, len(values))
+	for i, value := range values {
+		row[i] = w.csvString(value)
+	}
+	return w.writer.Write(row)
+}
+
+func (w *csvWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	// header only if no object was written
+	if w.columns != nil {
+		err := w.writeHeader()
+		if err != nil {
+			return err
+		}
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
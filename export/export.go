@@ -0,0 +1,395 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package export writes CCM and QM objects as JSON, NDJSON or CSV.
+//
+// Columns are addressed by paths of field names separated by dots
+// (e.g. "Owner.Name" or "owner.name"). Each path element can be the Go
+// field name or the name in the jazz, xml or json struct tag. Referenced
+// CCM objects are loaded if a path points into them.
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bboehmke/go-jazz"
+)
+
+// Column of an export
+type Column struct {
+	// Name of the column (used as CSV header and JSON key)
+	Name string
+
+	// Path of the value inside the object (e.g. "Owner.Name")
+	Path string
+}
+
+// Columns creates columns for the given paths (the path is used as name)
+func Columns(paths ...string) []Column {
+	columns := make([]Column, len(paths))
+	for i, path := range paths {
+		columns[i] = Column{
+			Name: path,
+			Path: path,
+		}
+	}
+	return columns
+}
+
+// Options of an export
+type Options struct {
+	// Context used to load referenced CCM objects (default: context.Background())
+	Context context.Context
+
+	// Columns to export (default: all fields of the first object)
+	Columns []Column
+
+	// TimeFormat used for all time values (default: time.RFC3339)
+	TimeFormat string
+
+	// ListSeparator used to join list values in CSV (default: "; ")
+	ListSeparator string
+}
+
+// Writer of objects
+type Writer interface {
+	// Write a single object
+	Write(obj interface{}) error
+
+	// Close finishes the export (does not close the underlying writer)
+	Close() error
+}
+
+// List writes all objects and closes the writer
+func List[T any](writer Writer, objects []T) error {
+	for _, obj := range objects {
+		err := writer.Write(obj)
+		if err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// Chan writes all objects send to the channel by f (e.g. jazz.CCMListChan)
+// and closes the writer
+func Chan[T any](writer Writer, f func(ch chan T) error) error {
+	ch := make(chan T)
+
+	// write all entries of the channel
+	var writeErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for entry := range ch {
+			if writeErr == nil {
+				writeErr = writer.Write(entry)
+			}
+		}
+	}()
+
+	err := f(ch)
+	close(ch)
+
+	// wait for all entries to be handled
+	wg.Wait()
+	if err != nil {
+		return err
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+	return writer.Close()
+}
+
+// loader is implemented by CCM objects that can be loaded from the server
+type loader interface {
+	Load(ctx context.Context) error
+}
+
+// types with special handling
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	durationTypes = map[reflect.Type]struct{}{
+		reflect.TypeOf(time.Duration(0)):    {},
+		reflect.TypeOf(jazz.CCMDuration(0)): {},
+		reflect.TypeOf(jazz.QMDuration(0)):  {},
+	}
+)
+
+// exporter contains the common logic of all writers
+type exporter struct {
+	options Options
+	columns []Column
+}
+
+// newExporter with the given options
+func newExporter(options Options) *exporter {
+	if options.Context == nil {
+		options.Context = context.Background()
+	}
+	if options.TimeFormat == "" {
+		options.TimeFormat = time.RFC3339
+	}
+	if options.ListSeparator == "" {
+		options.ListSeparator = "; "
+	}
+	return &exporter{
+		options: options,
+		columns: options.Columns,
+	}
+}
+
+// initColumns from the type of the first object if no columns are configured
+func (e *exporter) initColumns(obj interface{}) {
+	if e.columns != nil {
+		return
+	}
+
+	t := reflect.TypeOf(obj)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	e.columns = make([]Column, 0)
+	if t != nil && t.Kind() == reflect.Struct {
+		e.columns = defaultColumns(t, "")
+	}
+}
+
+// defaultColumns of all exported fields of the given struct type
+func defaultColumns(t reflect.Type, prefix string) []Column {
+	var columns []Column
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		// fields of embedded base objects
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			columns = append(columns, defaultColumns(field.Type, prefix)...)
+			continue
+		}
+
+		columns = append(columns, Column{
+			Name: tagName(field),
+			Path: prefix + field.Name,
+		})
+	}
+	return columns
+}
+
+// tagName returns the name of the field in the jazz, xml or json tag (Go name
+// if no tag exists)
+func tagName(field reflect.StructField) string {
+	for _, key := range []string{"jazz", "xml", "json"} {
+		name := strings.Split(field.Tag.Get(key), ",")[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// findField with the given Go or tag name in the struct type (including
+// fields of embedded structs)
+func findField(t reflect.Type, name string) ([]int, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.IsExported() && !field.Anonymous &&
+			(field.Name == name || tagName(field) == name) {
+			return field.Index, true
+		}
+	}
+
+	// search in embedded structs
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if index, ok := findField(field.Type, name); ok {
+				return append([]int{i}, index...), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// resolve the values at the given path. Returns true if the path contains a list.
+func (e *exporter) resolve(v reflect.Value, path []string) ([]reflect.Value, bool, error) {
+	// dereference pointers and interfaces
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false, nil
+		}
+
+		// load referenced CCM objects before accessing fields
+		if l, ok := v.Interface().(loader); ok && len(path) > 0 {
+			err := l.Load(e.options.Context)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		var values []reflect.Value
+		for i := 0; i < v.Len(); i++ {
+			elements, _, err := e.resolve(v.Index(i), path)
+			if err != nil {
+				return nil, false, err
+			}
+			values = append(values, elements...)
+		}
+		return values, true, nil
+	}
+
+	if len(path) == 0 {
+		return []reflect.Value{v}, false, nil
+	}
+
+	if v.Kind() != reflect.Struct || v.Type() == timeType {
+		return nil, false, fmt.Errorf("no field \"%s\" in %s", path[0], v.Type())
+	}
+	index, ok := findField(v.Type(), path[0])
+	if !ok {
+		return nil, false, fmt.Errorf("no field \"%s\" in %s", path[0], v.Type())
+	}
+	return e.resolve(v.FieldByIndex(index), path[1:])
+}
+
+// values of all columns of the given object
+func (e *exporter) values(obj interface{}) ([]columnValue, error) {
+	e.initColumns(obj)
+
+	values := make([]columnValue, len(e.columns))
+	for i, column := range e.columns {
+		var path []string
+		if column.Path != "" {
+			path = strings.Split(column.Path, ".")
+		}
+
+		elements, list, err := e.resolve(reflect.ValueOf(obj), path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export column %s: %w", column.Name, err)
+		}
+
+		values[i].list = list
+		values[i].values = make([]interface{}, len(elements))
+		for j, element := range elements {
+			values[i].values[j] = e.value(element)
+		}
+	}
+	return values, nil
+}
+
+// columnValue contains the values of a column
+type columnValue struct {
+	values []interface{}
+	list   bool
+}
+
+// value converts the given value to a JSON compatible value
+func (e *exporter) value(v reflect.Value) interface{} {
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(e.options.TimeFormat)
+	}
+
+	// all durations are exported in seconds
+	if _, ok := durationTypes[v.Type()]; ok {
+		return time.Duration(v.Int()).Seconds()
+	}
+
+	if stringer, ok := v.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+
+	case reflect.Map:
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[fmt.Sprint(iter.Key().Interface())] = e.value(iter.Value())
+		}
+		return m
+
+	case reflect.Struct:
+		// use identifier of referenced objects
+		for _, name := range []string{"Href", "ResourceUrl", "ItemId"} {
+			if index, ok := findField(v.Type(), name); ok {
+				return e.value(v.FieldByIndex(index))
+			}
+		}
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// csvString converts a column value to a CSV cell
+func (e *exporter) csvString(value columnValue) string {
+	strs := make([]string, len(value.values))
+	for i, v := range value.values {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			keys := make([]string, 0, len(t))
+			for key := range t {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			entries := make([]string, len(keys))
+			for j, key := range keys {
+				entries[j] = fmt.Sprintf("%s=%v", key, t[key])
+			}
+			strs[i] = strings.Join(entries, e.options.ListSeparator)
+		default:
+			strs[i] = fmt.Sprint(v)
+		}
+	}
+	return strings.Join(strs, e.options.ListSeparator)
+}
+
+// jsonValue converts a column value to a JSON value
+func (e *exporter) jsonValue(value columnValue) interface{} {
+	if value.list {
+		if value.values == nil {
+			return []interface{}{}
+		}
+		return value.values
+	}
+	if len(value.values) == 0 {
+		return nil
+	}
+	return value.values[0]
+}
+
+// errClosed is returned if a closed writer is used
+var errClosed = errors.New("export writer is closed")
@@ -0,0 +1,210 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bboehmke/go-jazz"
+)
+
+// testWorkItems returns loaded work items (not requested from a server)
+func testWorkItems() []*jazz.CCMWorkItem {
+	created := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	owner := &jazz.CCMContributor{
+		CCMBaseObject: jazz.CCMBaseObject{ItemId: "_user", ReportableUrl: "url"},
+		Name:          "User, Name",
+	}
+
+	return []*jazz.CCMWorkItem{
+		{
+			CCMBaseObject: jazz.CCMBaseObject{ItemId: "_1", ReportableUrl: "url"},
+			Id:            1,
+			Summary:       "first",
+			CreationDate:  &created,
+			Owner:         owner,
+			Tags:          "a",
+			TimeSpent:     5400000,
+			Subscriptions: []*jazz.CCMContributor{owner, {
+				CCMBaseObject: jazz.CCMBaseObject{ItemId: "_other", ReportableUrl: "url"},
+				Name:          "Other",
+			}},
+		},
+		{
+			CCMBaseObject: jazz.CCMBaseObject{ItemId: "_2", ReportableUrl: "url"},
+			Id:            2,
+			Summary:       "second \"quoted\"",
+		},
+	}
+}
+
+// testColumns used for all writer tests
+var testColumns = []Column{
+	{Name: "id", Path: "Id"},
+	{Name: "summary", Path: "summary"}, // jazz tag name
+	{Name: "created", Path: "CreationDate"},
+	{Name: "owner", Path: "Owner.Name"},
+	{Name: "owner id", Path: "owner"},
+	{Name: "spent", Path: "TimeSpent"},
+	{Name: "subscribers", Path: "Subscriptions.Name"},
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	err := List(NewCSVWriter(&buffer, Options{Columns: testColumns}), testWorkItems())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "id,summary,created,owner,owner id,spent,subscribers\n" +
+		"1,first,2022-03-01T12:00:00Z,\"User, Name\",_user,5400000,\"User, Name; Other\"\n" +
+		"2,\"second \"\"quoted\"\"\",,,,0,\n"
+	if buffer.String() != expected {
+		t.Errorf("got CSV:\n%s\nexpected:\n%s", buffer.String(), expected)
+	}
+}
+
+func TestCSVWriterOptions(t *testing.T) {
+	var buffer bytes.Buffer
+	err := List(NewCSVWriter(&buffer, Options{
+		Columns:       Columns("CreationDate", "Subscriptions.Name"),
+		TimeFormat:    "2006-01-02",
+		ListSeparator: "|",
+	}), testWorkItems()[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "CreationDate,Subscriptions.Name\n" +
+		"2022-03-01,\"User, Name|Other\"\n"
+	if buffer.String() != expected {
+		t.Errorf("got CSV:\n%s\nexpected:\n%s", buffer.String(), expected)
+	}
+}
+
+func TestCSVWriterEmpty(t *testing.T) {
+	var buffer bytes.Buffer
+	err := List[*jazz.CCMWorkItem](NewCSVWriter(&buffer, Options{Columns: Columns("Id", "Summary")}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buffer.String() != "Id,Summary\n" {
+		t.Errorf("expected only header, got %q", buffer.String())
+	}
+}
+
+func TestJSONWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	err := List(NewJSONWriter(&buffer, Options{Columns: testColumns}), testWorkItems())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "[\n" +
+		`{"id":1,"summary":"first","created":"2022-03-01T12:00:00Z","owner":"User, Name","owner id":"_user","spent":5400000,"subscribers":["User, Name","Other"]}` + ",\n" +
+		`{"id":2,"summary":"second \"quoted\"","created":null,"owner":null,"owner id":null,"spent":0,"subscribers":[]}` +
+		"\n]\n"
+	if buffer.String() != expected {
+		t.Errorf("got JSON:\n%s\nexpected:\n%s", buffer.String(), expected)
+	}
+}
+
+func TestJSONWriterEmpty(t *testing.T) {
+	var buffer bytes.Buffer
+	err := List[*jazz.CCMWorkItem](NewJSONWriter(&buffer, Options{}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buffer.String() != "[]\n" {
+		t.Errorf("expected empty array, got %q", buffer.String())
+	}
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	err := Chan(NewNDJSONWriter(&buffer, Options{Columns: Columns("Id", "Summary")}),
+		func(ch chan *jazz.CCMWorkItem) error {
+			for _, workItem := range testWorkItems() {
+				ch <- workItem
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"Id":1,"Summary":"first"}` + "\n" +
+		`{"Id":2,"Summary":"second \"quoted\""}` + "\n"
+	if buffer.String() != expected {
+		t.Errorf("got NDJSON:\n%s\nexpected:\n%s", buffer.String(), expected)
+	}
+}
+
+func TestWriterDefaultColumns(t *testing.T) {
+	type object struct {
+		Name    string `json:"name"`
+		Count   int
+		hidden  string
+		Created time.Time
+		Spent   jazz.CCMDuration
+	}
+
+	var buffer bytes.Buffer
+	err := List(NewCSVWriter(&buffer, Options{}), []object{{
+		Name:    "a",
+		Count:   2,
+		hidden:  "x",
+		Created: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+		Spent:   jazz.CCMDuration(90 * time.Minute),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "name,Count,Created,Spent\n" +
+		"a,2,2022-03-01T00:00:00Z,5400\n"
+	if buffer.String() != expected {
+		t.Errorf("got CSV:\n%s\nexpected:\n%s", buffer.String(), expected)
+	}
+}
+
+func TestWriterErrors(t *testing.T) {
+	var buffer bytes.Buffer
+	writer := NewJSONWriter(&buffer, Options{Columns: Columns("Unknown")})
+	err := writer.Write(testWorkItems()[0])
+	if err == nil {
+		t.Error("expected error for unknown column")
+	}
+
+	err = writer.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = writer.Write(testWorkItems()[0])
+	if !errors.Is(err, errClosed) {
+		t.Errorf("expected closed error, got %v", err)
+	}
+
+	// error of the source is returned
+	err = Chan(NewCSVWriter(&buffer, Options{}), func(ch chan *jazz.CCMWorkItem) error {
+		return errors.New("failed")
+	})
+	if err == nil || err.Error() != "failed" {
+		t.Errorf("expected error of source, got %v", err)
+	}
+}
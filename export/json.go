@@ -0,0 +1,129 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// jsonWriter writes objects as JSON objects
+type jsonWriter struct {
+	*exporter
+	writer io.Writer
+
+	// lines is true for NDJSON (one object per line without array)
+	lines bool
+
+	count  int
+	closed bool
+}
+
+// NewJSONWriter creates a writer that writes all objects as JSON array
+func NewJSONWriter(w io.Writer, options Options) Writer {
+	return &jsonWriter{
+		exporter: newExporter(options),
+		writer:   w,
+	}
+}
+
+// NewNDJSONWriter creates a writer that writes one JSON object per line
+func NewNDJSONWriter(w io.Writer, options Options) Writer {
+	return &jsonWriter{
+		exporter: newExporter(options),
+		writer:   w,
+		lines:    true,
+	}
+}
+
+// encode the object with keys in column order
+func (w *jsonWriter) encode(values []columnValue) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		key, err := json.Marshal(w.columns[i].Name)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(key)
+		buffer.WriteByte(':')
+
+		data, err := json.Marshal(w.jsonValue(value))
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(data)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+func (w *jsonWriter) Write(obj interface{}) error {
+	if w.closed {
+		return errClosed
+	}
+
+	values, err := w.values(obj)
+	if err != nil {
+		return err
+	}
+	data, err := w.encode(values)
+	if err != nil {
+		return err
+	}
+
+	var prefix, suffix string
+	if w.lines {
+		suffix = "\n"
+	} else if w.count == 0 {
+		prefix = "[\n"
+	} else {
+		prefix = ",\n"
+	}
+	w.count++
+
+	_, err = io.WriteString(w.writer, prefix)
+	if err != nil {
+		return err
+	}
+	_, err = w.writer.Write(data)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w.writer, suffix)
+	return err
+}
+
+func (w *jsonWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	if w.lines {
+		return nil
+	}
+	if w.count == 0 {
+		_, err := io.WriteString(w.writer, "[]\n")
+		return err
+	}
+	_, err := io.WriteString(w.writer, "\n]\n")
+	return err
+}
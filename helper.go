@@ -15,8 +15,11 @@
 package jazz
 
 import (
+	"encoding/csv"
 	"fmt"
+	"io"
 	"sync"
+	"time"
 )
 
 // Chan2List converts a channel to a slice
@@ -57,3 +60,25 @@ func listOnlyOnce[T any](entries []T, err error) (T, error) {
 
 	return entries[0], nil
 }
+
+// writeCSV with the given header and rows
+func writeCSV(w io.Writer, header []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write(header)
+	if err != nil {
+		return err
+	}
+	err = writer.WriteAll(rows)
+	if err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// formatCSVTime in RFC3339 format (empty string for nil)
+func formatCSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
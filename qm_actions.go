@@ -0,0 +1,146 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// QMAction that modifies the state of QM objects
+type QMAction string
+
+const (
+	// QMActionDelete removes objects permanently
+	QMActionDelete QMAction = "delete"
+
+	// QMActionArchive moves objects to the archive
+	QMActionArchive QMAction = "archive"
+
+	// QMActionRestore restores archived objects
+	QMActionRestore QMAction = "restore"
+)
+
+// QMDelete object of the given type with the given ID. If dryRun is set
+// only the existence of the object is checked.
+func QMDelete[T QMObject](ctx context.Context, proj *QMProject, id string, dryRun bool) error {
+	return qmAction[T](ctx, proj, id, QMActionDelete, dryRun)
+}
+
+// QMArchive object of the given type with the given ID. If dryRun is set
+// only the existence of the object is checked.
+func QMArchive[T QMObject](ctx context.Context, proj *QMProject, id string, dryRun bool) error {
+	return qmAction[T](ctx, proj, id, QMActionArchive, dryRun)
+}
+
+// QMRestore archived object of the given type with the given ID. If dryRun
+// is set only the existence of the object is checked.
+func QMRestore[T QMObject](ctx context.Context, proj *QMProject, id string, dryRun bool) error {
+	return qmAction[T](ctx, proj, id, QMActionRestore, dryRun)
+}
+
+// QMBulk executes the action for all objects of the given type matching the
+// filter and returns the IDs of the objects the action was executed for. If
+// dryRun is set only the IDs of the matching objects are returned without
+// modification. An empty filter is rejected, use QMFilterAll to execute the
+// action for all objects.
+//
+// For QMActionRestore the filter is applied to archived objects.
+func QMBulk[T QMObject](ctx context.Context, proj *QMProject, filter QMFilter, action QMAction, dryRun bool) ([]string, error) {
+	spec := (*new(T)).Spec()
+	if filter.empty() && !filter.all() {
+		return nil, fmt.Errorf("empty filter would %s all %s objects (use QMFilterAll)", action, spec.ResourceID)
+	}
+
+	url, err := spec.ListURL(proj, filter)
+	if err != nil {
+		return nil, err
+	}
+	if action == QMActionRestore {
+		url = qmArchivedURL(url)
+	}
+
+	// request matching objects
+	entries, err := Chan2List[FeedEntry](func(ch chan FeedEntry) error {
+		return proj.qm.client.requestFeed(ctx, url, ch, false)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", spec.ResourceID, err)
+	}
+
+	ids := make([]string, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.Id
+	}
+	if dryRun {
+		return ids, nil
+	}
+
+	// execute action in parallel (stops on first error)
+	processed := make([]bool, len(ids))
+	err = qmParallel(ctx, proj, len(ids), func(ctx context.Context, index int) error {
+		err := qmAction[T](ctx, proj, ids[index], action, false)
+		processed[index] = err == nil
+		return err
+	})
+
+	processedIds := make([]string, 0, len(ids))
+	for i, id := range ids {
+		if processed[i] {
+			processedIds = append(processedIds, id)
+		}
+	}
+	return processedIds, err
+}
+
+// qmArchivedURL adds the parameter to list archived objects to the URL
+func qmArchivedURL(url string) string {
+	if strings.Contains(url, "?") {
+		return url + "&archived=true"
+	}
+	return url + "?archived=true"
+}
+
+// qmAction executes the action for the object with the given ID (or only
+// requests the object if dryRun is set)
+func qmAction[T QMObject](ctx context.Context, proj *QMProject, id string, action QMAction, dryRun bool) error {
+	spec := (*new(T)).Spec()
+	url := spec.GetURL(proj, id)
+
+	var response *http.Response
+	var err error
+	switch {
+	case action != QMActionDelete && action != QMActionArchive && action != QMActionRestore:
+		return fmt.Errorf("unknown action \"%s\"", action)
+	case dryRun:
+		response, err = proj.qm.client.get(ctx, url, "application/xml", false)
+	case action == QMActionDelete:
+		response, err = proj.qm.client.delete(ctx, url)
+	default:
+		response, err = proj.qm.client.post(ctx, fmt.Sprintf("%s?action=%s", url, action),
+			"application/xml", nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to %s %s: %w", action, spec.ResourceID, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		return errorFromResponse(fmt.Sprintf("failed to %s %s", action, spec.ResourceID), response, nil)
+	}
+	return nil
+}
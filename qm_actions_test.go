@@ -0,0 +1,208 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// qmTestActionHandler lists the given test case IDs, records all other
+// requests as "<method> <ID>[?<query>]" and fails for IDs in failing
+func qmTestActionHandler(ids []string, failing map[string]bool) (http.HandlerFunc, func() []string) {
+	var mutex sync.Mutex
+	var requests []string
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		id := path.Base(r.URL.Path)
+		if id == "testcase" {
+			var entries []string
+			for _, id := range ids {
+				entries = append(entries, fmt.Sprintf(`{"id":"%s"}`, id))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"feed":{"Entry":[%s]}}`, strings.Join(entries, ","))
+			return
+		}
+
+		request := r.Method + " " + id
+		if r.URL.RawQuery != "" {
+			request += "?" + r.URL.RawQuery
+		}
+		mutex.Lock()
+		requests = append(requests, request)
+		mutex.Unlock()
+
+		if failing[id] {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return handler, func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return requests
+	}
+}
+
+func TestQMAction(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  func(ctx context.Context, proj *QMProject, id string, dryRun bool) error
+		dryRun  bool
+		request string
+	}{
+		{"delete", QMDelete[*QMTestCase], false, "DELETE tc1"},
+		{"archive", QMArchive[*QMTestCase], false, "POST tc1?action=archive"},
+		{"restore", QMRestore[*QMTestCase], false, "POST tc1?action=restore"},
+		{"delete dry run", QMDelete[*QMTestCase], true, "GET tc1"},
+		{"archive dry run", QMArchive[*QMTestCase], true, "GET tc1"},
+		{"restore dry run", QMRestore[*QMTestCase], true, "GET tc1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler, requests := qmTestActionHandler(nil, nil)
+			proj := qmTestProject(t, handler)
+
+			err := test.action(context.Background(), proj, "tc1", test.dryRun)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Join(requests(), ","); got != test.request {
+				t.Errorf("got requests %s, expected %s", got, test.request)
+			}
+		})
+	}
+}
+
+func TestQMActionErrors(t *testing.T) {
+	handler, requests := qmTestActionHandler(nil, map[string]bool{"tc1": true})
+	proj := qmTestProject(t, handler)
+
+	// failed request
+	err := QMDelete[*QMTestCase](context.Background(), proj, "tc1", false)
+	if err == nil || !strings.Contains(err.Error(), "failed to delete testcase") {
+		t.Errorf("expected error of request, got %v", err)
+	}
+
+	// dry run of missing object
+	err = QMArchive[*QMTestCase](context.Background(), proj, "tc1", true)
+	if err == nil {
+		t.Error("expected error of dry run")
+	}
+
+	// unknown actions are not requested
+	err = qmAction[*QMTestCase](context.Background(), proj, "tc2", "unknown", false)
+	if err == nil || !strings.Contains(err.Error(), "unknown action") {
+		t.Errorf("expected error of unknown action, got %v", err)
+	}
+	if got := strings.Join(requests(), ","); got != "DELETE tc1,GET tc1" {
+		t.Errorf("got requests %s", got)
+	}
+}
+
+func TestQMBulk(t *testing.T) {
+	handler, requests := qmTestActionHandler([]string{"tc1", "tc2"}, nil)
+	proj := qmTestProject(t, handler)
+
+	ids, err := QMBulk[*QMTestCase](context.Background(), proj, QMFilterAll, QMActionArchive, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(ids, ",") != "tc1,tc2" {
+		t.Errorf("got IDs %v", ids)
+	}
+	if len(requests()) != 0 {
+		t.Errorf("expected no requests for dry run, got %v", requests())
+	}
+
+	ids, err = QMBulk[*QMTestCase](context.Background(), proj, QMFilterAll, QMActionArchive, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(ids, ",") != "tc1,tc2" {
+		t.Errorf("got IDs %v", ids)
+	}
+	if len(requests()) != 2 {
+		t.Errorf("expected 2 requests, got %v", requests())
+	}
+}
+
+func TestQMBulkEmptyFilter(t *testing.T) {
+	handler, requests := qmTestActionHandler([]string{"tc1"}, nil)
+	proj := qmTestProject(t, handler)
+
+	for _, filter := range []QMFilter{nil, {}} {
+		for _, dryRun := range []bool{true, false} {
+			ids, err := QMBulk[*QMTestCase](context.Background(), proj, filter, QMActionDelete, dryRun)
+			if err == nil || !strings.Contains(err.Error(), "empty filter would delete all testcase objects") {
+				t.Errorf("expected error for empty filter, got %v", err)
+			}
+			if ids != nil {
+				t.Errorf("expected no IDs, got %v", ids)
+			}
+		}
+	}
+	if len(requests()) != 0 {
+		t.Errorf("expected no requests, got %v", requests())
+	}
+
+	// all objects must be requested explicitly
+	url, err := (&QMTestCase{}).Spec().ListURL(proj, QMFilterAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(url, "?") {
+		t.Errorf("expected URL without filter, got %s", url)
+	}
+}
+
+func TestQMBulkError(t *testing.T) {
+	ids := make([]string, 50)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("tc%d", i+1)
+	}
+	handler, requests := qmTestActionHandler(ids, map[string]bool{"tc3": true})
+	proj := qmTestProject(t, handler)
+	proj.qm.client.Worker = 1
+
+	processed, err := QMBulk[*QMTestCase](context.Background(), proj, QMFilterAll, QMActionDelete, false)
+	if err == nil || !strings.Contains(err.Error(), "failed to delete testcase") {
+		t.Errorf("expected error of first action, got %v", err)
+	}
+	if got := strings.Join(requests(), ","); got != "DELETE tc1,DELETE tc2,DELETE tc3" {
+		t.Errorf("expected stop after first error, got requests %s", got)
+	}
+	if got := strings.Join(processed, ","); got != "tc1,tc2" {
+		t.Errorf("expected only processed IDs, got %s", got)
+	}
+}
+
+func TestQMArchivedURL(t *testing.T) {
+	if url := qmArchivedURL("testcase"); url != "testcase?archived=true" {
+		t.Errorf("got URL %s", url)
+	}
+	if url := qmArchivedURL("testcase?fields=x"); url != "testcase?fields=x&archived=true" {
+		t.Errorf("got URL %s", url)
+	}
+}
@@ -0,0 +1,345 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QMTestReport contains test results of an external test framework
+type QMTestReport struct {
+	// Cases of the report
+	Cases []QMTestReportCase
+}
+
+// QMTestReportCase is the result of a single test of a QMTestReport
+type QMTestReportCase struct {
+	// Suite (or class) the test is part of
+	Suite string
+
+	// Name of test
+	Name string
+
+	// State of the test (see QMResultState*)
+	State string
+
+	// StartTime of test execution
+	StartTime time.Time
+
+	// Duration of test execution
+	Duration time.Duration
+
+	// Machine the test was executed on
+	Machine string
+
+	// Message of a failed test
+	Message string
+
+	// Details of a failed test (e.g. stack trace)
+	Details string
+
+	// Stdout of test
+	Stdout string
+
+	// Stderr of test
+	Stderr string
+
+	// Properties of test (added as variables to the execution result)
+	Properties map[string]string
+}
+
+// FullName of test (suite and name separated by a dot)
+func (c QMTestReportCase) FullName() string {
+	if c.Suite == "" {
+		return c.Name
+	}
+	return c.Suite + "." + c.Name
+}
+
+// QMImportMatch defines how tests of a report are matched to test cases
+type QMImportMatch string
+
+const (
+	// QMImportMatchTitle matches the full name or name of a test with the
+	// title of the test case
+	QMImportMatchTitle QMImportMatch = "title"
+
+	// QMImportMatchWebId matches the number found by QMImporter.WebIdPattern
+	// in the name of a test with the web ID of the test case
+	QMImportMatchWebId QMImportMatch = "webId"
+)
+
+// QMImporter creates test execution results from test reports
+type QMImporter struct {
+	// TestPlan the results are created for
+	TestPlan *QMTestPlan
+
+	// TestEnvironment of the results (optional)
+	TestEnvironment *QMTestEnvironment
+
+	// Match defines how tests are matched to test cases (default: QMImportMatchTitle)
+	Match QMImportMatch
+
+	// WebIdPattern is used to find the web ID in the test name for
+	// QMImportMatchWebId (the first sub match is used)
+	WebIdPattern *regexp.Regexp
+
+	// Mapping of test names (full name or name) to test cases (title, web ID
+	// or resource URL). Mapped tests are not matched with Match.
+	Mapping map[string]string
+
+	// Machine used if the report contains no machine
+	Machine string
+
+	// Variables added to every execution result
+	Variables QMVariableMap
+
+	// SkipOutput disables the upload of failure details, stdout and stderr
+	// as attachments
+	SkipOutput bool
+
+	// test cases of the test plan
+	testCases        []*QMTestCase
+	executionRecords map[string]*QMTestExecutionRecord
+}
+
+// NewQMImporter creates an importer for the given test plan
+func NewQMImporter(testPlan *QMTestPlan) *QMImporter {
+	return &QMImporter{
+		TestPlan:     testPlan,
+		Match:        QMImportMatchTitle,
+		WebIdPattern: regexp.MustCompile(`(\d+)`),
+	}
+}
+
+// QMImportResult contains the result of an import
+type QMImportResult struct {
+	// Results created for the tests of the report
+	Results []*QMTestExecutionResult
+
+	// Unmapped tests without a matching test case
+	Unmapped []QMTestReportCase
+}
+
+// Import creates an execution result for every test of the report that
+// matches a test case. The matching execution record of the test plan is
+// created if not existing.
+func (i *QMImporter) Import(ctx context.Context, report *QMTestReport) (*QMImportResult, error) {
+	if i.TestPlan == nil {
+		return nil, errors.New("failed to import report: no test plan")
+	}
+
+	result := &QMImportResult{
+		Results:  make([]*QMTestExecutionResult, 0, len(report.Cases)),
+		Unmapped: make([]QMTestReportCase, 0),
+	}
+	for _, reportCase := range report.Cases {
+		testCase, err := i.testCase(ctx, reportCase)
+		if err != nil {
+			return result, fmt.Errorf("failed to import %s: %w", reportCase.FullName(), err)
+		}
+		if testCase == nil {
+			result.Unmapped = append(result.Unmapped, reportCase)
+			continue
+		}
+
+		executionResult, err := i.importCase(ctx, testCase, reportCase)
+		if err != nil {
+			return result, fmt.Errorf("failed to import %s: %w", reportCase.FullName(), err)
+		}
+		result.Results = append(result.Results, executionResult)
+	}
+	return result, nil
+}
+
+// loadTestCases of the test plan (only once)
+func (i *QMImporter) loadTestCases(ctx context.Context) error {
+	if i.testCases != nil {
+		return nil
+	}
+
+	testCases, err := qmGetList[*QMTestCase](ctx, i.TestPlan.proj, i.TestPlan.TestCaseRefs.IDList())
+	if err != nil {
+		return fmt.Errorf("failed to load test cases: %w", err)
+	}
+	i.testCases = testCases
+	return nil
+}
+
+// testCase matching the given test (nil if not found)
+func (i *QMImporter) testCase(ctx context.Context, reportCase QMTestReportCase) (*QMTestCase, error) {
+	err := i.loadTestCases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// explicit mapping
+	for _, name := range []string{reportCase.FullName(), reportCase.Name} {
+		id, ok := i.Mapping[name]
+		if !ok {
+			continue
+		}
+
+		for _, testCase := range i.testCases {
+			if testCase.Title == id || strconv.Itoa(testCase.WebId) == id || testCase.ResourceUrl == id {
+				return testCase, nil
+			}
+		}
+		return QMGet[*QMTestCase](ctx, i.TestPlan.proj, id)
+	}
+
+	switch i.Match {
+	case QMImportMatchWebId:
+		if i.WebIdPattern == nil {
+			return nil, errors.New("no web ID pattern")
+		}
+		match := i.WebIdPattern.FindStringSubmatch(reportCase.Name)
+		if len(match) < 2 {
+			return nil, nil
+		}
+		for _, testCase := range i.testCases {
+			if strconv.Itoa(testCase.WebId) == match[1] {
+				return testCase, nil
+			}
+		}
+
+	case QMImportMatchTitle, "":
+		for _, name := range []string{reportCase.FullName(), reportCase.Name} {
+			for _, testCase := range i.testCases {
+				if testCase.Title == name {
+					return testCase, nil
+				}
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown match \"%s\"", i.Match)
+	}
+	return nil, nil
+}
+
+// executionRecord of the given test case (created if not exist)
+func (i *QMImporter) executionRecord(ctx context.Context, testCase *QMTestCase) (*QMTestExecutionRecord, error) {
+	if record, ok := i.executionRecords[testCase.ResourceUrl]; ok {
+		return record, nil
+	}
+
+	filter := QMFilter{
+		"testplan/@href": i.TestPlan.ResourceUrl,
+		"testcase/@href": testCase.ResourceUrl,
+	}
+	if i.TestEnvironment != nil {
+		filter["configuration/@href"] = i.TestEnvironment.ResourceUrl
+	}
+	records, err := QMList[*QMTestExecutionRecord](ctx, i.TestPlan.proj, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get execution record: %w", err)
+	}
+
+	var record *QMTestExecutionRecord
+	if len(records) > 0 {
+		record = records[0]
+	} else {
+		record = &QMTestExecutionRecord{
+			Title:       testCase.Title,
+			TestPlanRef: i.TestPlan.Ref(),
+			TestCaseRef: testCase.Ref(),
+		}
+		if i.TestEnvironment != nil {
+			record.TestEnvironmentRef = i.TestEnvironment.Ref()
+		}
+
+		record, err = QMSave(ctx, i.TestPlan.proj, record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create execution record: %w", err)
+		}
+	}
+
+	if i.executionRecords == nil {
+		i.executionRecords = make(map[string]*QMTestExecutionRecord)
+	}
+	i.executionRecords[testCase.ResourceUrl] = record
+	return record, nil
+}
+
+// importCase creates the execution result of a single test
+func (i *QMImporter) importCase(ctx context.Context, testCase *QMTestCase, reportCase QMTestReportCase) (*QMTestExecutionResult, error) {
+	record, err := i.executionRecord(ctx, testCase)
+	if err != nil {
+		return nil, err
+	}
+
+	// use import time for reports without times
+	startTime := reportCase.StartTime
+	if startTime.IsZero() {
+		startTime = time.Now()
+	}
+
+	result := &QMTestExecutionResult{
+		State:                  reportCase.State,
+		Machine:                reportCase.Machine,
+		StartTime:              startTime,
+		EndTime:                startTime.Add(reportCase.Duration),
+		Variables:              make(QMVariableMap),
+		TestPlanRef:            i.TestPlan.Ref(),
+		TestCaseRef:            testCase.Ref(),
+		TestExecutionRecordRef: record.Ref(),
+	}
+	if result.Machine == "" {
+		result.Machine = i.Machine
+	}
+	if i.TestEnvironment != nil {
+		result.TestEnvironmentRef = i.TestEnvironment.Ref()
+	}
+	for key, value := range i.Variables {
+		result.Variables[key] = value
+	}
+	for key, value := range reportCase.Properties {
+		result.Variables[key] = value
+	}
+
+	// upload output as attachments
+	if !i.SkipOutput {
+		outputs := []struct {
+			suffix  string
+			content string
+		}{
+			{"failure", strings.TrimSpace(reportCase.Message + "\n\n" + reportCase.Details)},
+			{"stdout", reportCase.Stdout},
+			{"stderr", reportCase.Stderr},
+		}
+		for _, output := range outputs {
+			if output.content == "" {
+				continue
+			}
+
+			attachment, err := i.TestPlan.proj.UploadAttachment(ctx,
+				fmt.Sprintf("%s-%s.txt", reportCase.FullName(), output.suffix),
+				strings.NewReader(output.content))
+			if err != nil {
+				return nil, err
+			}
+			result.AttachmentRefs = append(result.AttachmentRefs, attachment.Ref())
+		}
+	}
+
+	return QMSave(ctx, i.TestPlan.proj, result)
+}
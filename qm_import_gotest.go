@@ -0,0 +1,97 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// goTestEvent is a single line of the "go test -json" output
+type goTestEvent struct {
+	Time    time.Time `json:"Time"`
+	Action  string    `json:"Action"`
+	Package string    `json:"Package"`
+	Test    string    `json:"Test"`
+	Elapsed float64   `json:"Elapsed"`
+	Output  string    `json:"Output"`
+}
+
+// ParseGoTestReport parses the output of "go test -json". Tests that were
+// started but not finished (e.g. because of a panic) are reported as error.
+func ParseGoTestReport(r io.Reader) (*QMTestReport, error) {
+	cases := make(map[string]*QMTestReportCase)
+	output := make(map[string]*strings.Builder)
+	var order []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "{") {
+			// ignore non JSON output (e.g. build errors)
+			continue
+		}
+
+		var event goTestEvent
+		err := json.Unmarshal([]byte(line), &event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse go test report: %w", err)
+		}
+
+		// only events of tests are relevant
+		if event.Test == "" {
+			continue
+		}
+
+		key := event.Package + "/" + event.Test
+		reportCase, ok := cases[key]
+		if !ok {
+			reportCase = &QMTestReportCase{
+				Suite:     event.Package,
+				Name:      event.Test,
+				State:     QMReportState("error"),
+				StartTime: event.Time,
+			}
+			cases[key] = reportCase
+			output[key] = new(strings.Builder)
+			order = append(order, key)
+		}
+
+		switch event.Action {
+		case "output":
+			output[key].WriteString(event.Output)
+		case "pass", "fail", "skip":
+			reportCase.State = QMReportState(event.Action)
+			reportCase.Duration = time.Duration(event.Elapsed * float64(time.Second))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse go test report: %w", err)
+	}
+
+	report := &QMTestReport{
+		Cases: make([]QMTestReportCase, len(order)),
+	}
+	for i, key := range order {
+		report.Cases[i] = *cases[key]
+		report.Cases[i].Stdout = strings.TrimSpace(output[key].String())
+	}
+	return report, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseGoTestReport(t *testing.T) {
+	report, err := ParseGoTestReport(strings.NewReader(`# example.com/pkg [build output]
+{"Time":"2022-03-01T12:00:00Z","Action":"start","Package":"example.com/pkg"}
+{"Time":"2022-03-01T12:00:00Z","Action":"run","Package":"example.com/pkg","Test":"TestPass"}
+{"Time":"2022-03-01T12:00:00Z","Action":"output","Package":"example.com/pkg","Test":"TestPass","Output":"=== RUN   TestPass\n"}
+{"Time":"2022-03-01T12:00:01Z","Action":"pass","Package":"example.com/pkg","Test":"TestPass","Elapsed":1.5}
+{"Time":"2022-03-01T12:00:01Z","Action":"run","Package":"example.com/pkg","Test":"TestFail"}
+{"Time":"2022-03-01T12:00:01Z","Action":"output","Package":"example.com/pkg","Test":"TestFail","Output":"    fail_test.go:10: wrong\n"}
+{"Time":"2022-03-01T12:00:01Z","Action":"fail","Package":"example.com/pkg","Test":"TestFail","Elapsed":0.25}
+{"Time":"2022-03-01T12:00:02Z","Action":"run","Package":"example.com/pkg","Test":"TestSkip"}
+{"Time":"2022-03-01T12:00:02Z","Action":"skip","Package":"example.com/pkg","Test":"TestSkip","Elapsed":0}
+{"Time":"2022-03-01T12:00:03Z","Action":"run","Package":"example.com/pkg","Test":"TestPanic"}
+{"Time":"2022-03-01T12:00:03Z","Action":"output","Package":"example.com/pkg","Test":"TestPanic","Output":"panic: boom\n"}
+{"Time":"2022-03-01T12:00:03Z","Action":"fail","Package":"example.com/pkg","Elapsed":3}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	expected := []QMTestReportCase{
+		{
+			Suite:     "example.com/pkg",
+			Name:      "TestPass",
+			State:     QMResultStatePassed,
+			StartTime: start,
+			Duration:  1500 * time.Millisecond,
+			Stdout:    "=== RUN   TestPass",
+		},
+		{
+			Suite:     "example.com/pkg",
+			Name:      "TestFail",
+			State:     QMResultStateFailed,
+			StartTime: start.Add(time.Second),
+			Duration:  250 * time.Millisecond,
+			Stdout:    "fail_test.go:10: wrong",
+		},
+		{
+			Suite:     "example.com/pkg",
+			Name:      "TestSkip",
+			State:     QMResultStateDeferred,
+			StartTime: start.Add(2 * time.Second),
+		},
+		{
+			Suite:     "example.com/pkg",
+			Name:      "TestPanic",
+			State:     QMResultStateError,
+			StartTime: start.Add(3 * time.Second),
+			Stdout:    "panic: boom",
+		},
+	}
+
+	if len(report.Cases) != len(expected) {
+		t.Fatalf("got %d cases, expected %d", len(report.Cases), len(expected))
+	}
+	for i, reportCase := range report.Cases {
+		if !reflect.DeepEqual(reportCase, expected[i]) {
+			t.Errorf("case %d:\ngot      %+v\nexpected %+v", i, reportCase, expected[i])
+		}
+	}
+}
+
+func TestParseGoTestReportInvalidJSON(t *testing.T) {
+	_, err := ParseGoTestReport(strings.NewReader(`{"Action":`))
+	if err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// junitTestSuite of a JUnit XML report
+type junitTestSuite struct {
+	Name       string           `xml:"name,attr"`
+	Timestamp  string           `xml:"timestamp,attr"`
+	Hostname   string           `xml:"hostname,attr"`
+	Properties []junitProperty  `xml:"properties>property"`
+	Cases      []junitTestCase  `xml:"testcase"`
+	Suites     []junitTestSuite `xml:"testsuite"`
+}
+
+// junitProperty of a JUnit test suite or test case
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// junitTestCase of a JUnit XML report
+type junitTestCase struct {
+	ClassName  string          `xml:"classname,attr"`
+	Name       string          `xml:"name,attr"`
+	Time       string          `xml:"time,attr"`
+	Failure    *junitMessage   `xml:"failure"`
+	Error      *junitMessage   `xml:"error"`
+	Skipped    *junitMessage   `xml:"skipped"`
+	Stdout     string          `xml:"system-out"`
+	Stderr     string          `xml:"system-err"`
+	Properties []junitProperty `xml:"properties>property"`
+}
+
+// junitMessage of a failed, errored or skipped JUnit test case
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ParseJUnitReport parses a JUnit XML report (with "testsuites" or
+// "testsuite" as root element)
+func ParseJUnitReport(r io.Reader) (*QMTestReport, error) {
+	decoder := xml.NewDecoder(r)
+	root, err := xmlRootElement(decoder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JUnit report: %w", err)
+	}
+
+	var suites []junitTestSuite
+	switch root.Name.Local {
+	case "testsuites":
+		var buffer struct {
+			Suites []junitTestSuite `xml:"testsuite"`
+		}
+		err := decoder.DecodeElement(&buffer, &root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JUnit report: %w", err)
+		}
+		suites = buffer.Suites
+
+	case "testsuite":
+		var suite junitTestSuite
+		err := decoder.DecodeElement(&suite, &root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JUnit report: %w", err)
+		}
+		suites = []junitTestSuite{suite}
+
+	default:
+		return nil, fmt.Errorf("failed to parse JUnit report: unexpected root element \"%s\"", root.Name.Local)
+	}
+
+	report := &QMTestReport{
+		Cases: make([]QMTestReportCase, 0),
+	}
+	for _, suite := range suites {
+		err := report.addJUnitSuite(suite, nil, time.Time{}, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JUnit report: %w", err)
+		}
+	}
+	return report, nil
+}
+
+// addJUnitSuite adds the test cases of the suite (and its child suites)
+func (r *QMTestReport) addJUnitSuite(suite junitTestSuite, properties map[string]string, startTime time.Time, hostname string) error {
+	// inherit values of parent suite
+	if suite.Timestamp != "" {
+		var err error
+		startTime, err = parseJUnitTimestamp(suite.Timestamp)
+		if err != nil {
+			return err
+		}
+	}
+	if suite.Hostname != "" {
+		hostname = suite.Hostname
+	}
+	suiteProperties := make(map[string]string, len(properties)+len(suite.Properties))
+	for key, value := range properties {
+		suiteProperties[key] = value
+	}
+	for _, property := range suite.Properties {
+		suiteProperties[property.Name] = property.Value
+	}
+
+	for _, testCase := range suite.Cases {
+		duration, err := parseReportSeconds(testCase.Time)
+		if err != nil {
+			return err
+		}
+
+		reportCase := QMTestReportCase{
+			Suite:      testCase.ClassName,
+			Name:       testCase.Name,
+			State:      QMReportState("passed"),
+			StartTime:  startTime,
+			Duration:   duration,
+			Machine:    hostname,
+			Stdout:     strings.TrimSpace(testCase.Stdout),
+			Stderr:     strings.TrimSpace(testCase.Stderr),
+			Properties: make(map[string]string, len(suiteProperties)+len(testCase.Properties)),
+		}
+		if reportCase.Suite == "" {
+			reportCase.Suite = suite.Name
+		}
+		for key, value := range suiteProperties {
+			reportCase.Properties[key] = value
+		}
+		for _, property := range testCase.Properties {
+			reportCase.Properties[property.Name] = property.Value
+		}
+
+		// state of test case
+		var message *junitMessage
+		switch {
+		case testCase.Error != nil:
+			reportCase.State = QMReportState("error")
+			message = testCase.Error
+		case testCase.Failure != nil:
+			reportCase.State = QMReportState("failure")
+			message = testCase.Failure
+		case testCase.Skipped != nil:
+			reportCase.State = QMReportState("skipped")
+			message = testCase.Skipped
+		}
+		if message != nil {
+			reportCase.Message = message.Message
+			reportCase.Details = strings.TrimSpace(message.Text)
+		}
+
+		r.Cases = append(r.Cases, reportCase)
+
+		// test cases of a suite are executed one after another
+		if !startTime.IsZero() {
+			startTime = startTime.Add(duration)
+		}
+	}
+
+	for _, child := range suite.Suites {
+		err := r.addJUnitSuite(child, suiteProperties, startTime, hostname)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseJUnitTimestamp of a test suite (ISO 8601 with or without time zone)
+func parseJUnitTimestamp(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err == nil {
+		return t, nil
+	}
+	t, err = time.Parse("2006-01-02T15:04:05.999999999", s)
+	if err != nil {
+		return t, fmt.Errorf("invalid timestamp \"%s\"", s)
+	}
+	return t, nil
+}
+
+// parseReportSeconds parses a duration in seconds (e.g. "1.5" or "1,024.5")
+func parseReportSeconds(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	seconds, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time \"%s\"", s)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// ImportJUnit creates the execution results of a JUnit XML report
+func (i *QMImporter) ImportJUnit(ctx context.Context, r io.Reader) (*QMImportResult, error) {
+	return i.ImportReport(ctx, QMReportParserFunc(ParseJUnitReport), r)
+}
@@ -0,0 +1,161 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseJUnitReport(t *testing.T) {
+	report, err := ParseJUnitReport(strings.NewReader(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="suite" timestamp="2022-03-01T12:00:00" hostname="host">
+    <properties>
+      <property name="os" value="linux"/>
+      <property name="arch" value="amd64"/>
+    </properties>
+    <testcase classname="pkg.Class" name="passed" time="1.5">
+      <system-out>
+        output
+      </system-out>
+    </testcase>
+    <testcase classname="pkg.Class" name="failed" time="1,000">
+      <failure message="expected 1">stack trace</failure>
+      <system-err>error output</system-err>
+    </testcase>
+    <testcase name="errored" time="0.5">
+      <error message="panic"/>
+      <properties>
+        <property name="arch" value="arm64"/>
+      </properties>
+    </testcase>
+    <testcase classname="pkg.Class" name="skipped">
+      <skipped/>
+    </testcase>
+    <testsuite name="child" hostname="other">
+      <testcase classname="pkg.Child" name="nested" time="2"/>
+    </testsuite>
+  </testsuite>
+</testsuites>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	expected := []QMTestReportCase{
+		{
+			Suite:      "pkg.Class",
+			Name:       "passed",
+			State:      QMResultStatePassed,
+			StartTime:  start,
+			Duration:   1500 * time.Millisecond,
+			Machine:    "host",
+			Stdout:     "output",
+			Properties: map[string]string{"os": "linux", "arch": "amd64"},
+		},
+		{
+			Suite:      "pkg.Class",
+			Name:       "failed",
+			State:      QMResultStateFailed,
+			StartTime:  start.Add(1500 * time.Millisecond),
+			Duration:   1000 * time.Second,
+			Machine:    "host",
+			Message:    "expected 1",
+			Details:    "stack trace",
+			Stderr:     "error output",
+			Properties: map[string]string{"os": "linux", "arch": "amd64"},
+		},
+		{
+			Suite:      "suite",
+			Name:       "errored",
+			State:      QMResultStateError,
+			StartTime:  start.Add(1001500 * time.Millisecond),
+			Duration:   500 * time.Millisecond,
+			Machine:    "host",
+			Message:    "panic",
+			Properties: map[string]string{"os": "linux", "arch": "arm64"},
+		},
+		{
+			Suite:      "pkg.Class",
+			Name:       "skipped",
+			State:      QMResultStateDeferred,
+			StartTime:  start.Add(1002 * time.Second),
+			Machine:    "host",
+			Properties: map[string]string{"os": "linux", "arch": "amd64"},
+		},
+		{
+			Suite:      "pkg.Child",
+			Name:       "nested",
+			State:      QMResultStatePassed,
+			StartTime:  start.Add(1002 * time.Second),
+			Duration:   2 * time.Second,
+			Machine:    "other",
+			Properties: map[string]string{"os": "linux", "arch": "amd64"},
+		},
+	}
+
+	if len(report.Cases) != len(expected) {
+		t.Fatalf("got %d cases, expected %d", len(report.Cases), len(expected))
+	}
+	for i, reportCase := range report.Cases {
+		if !reflect.DeepEqual(reportCase, expected[i]) {
+			t.Errorf("case %d:\ngot      %+v\nexpected %+v", i, reportCase, expected[i])
+		}
+	}
+}
+
+func TestParseJUnitReportSingleSuite(t *testing.T) {
+	report, err := ParseJUnitReport(strings.NewReader(`<testsuite name="suite" timestamp="2022-03-01T12:00:00+01:00">
+  <testcase name="test" time="1"/>
+</testsuite>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Cases) != 1 {
+		t.Fatalf("got %d cases, expected 1", len(report.Cases))
+	}
+	reportCase := report.Cases[0]
+	if reportCase.FullName() != "suite.test" {
+		t.Errorf("got name %s, expected suite.test", reportCase.FullName())
+	}
+	if !reportCase.StartTime.Equal(time.Date(2022, 3, 1, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("got start time %s", reportCase.StartTime)
+	}
+}
+
+func TestParseJUnitReportErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		report string
+	}{
+		{"empty", ""},
+		{"unknown root", "<report/>"},
+		{"invalid timestamp", `<testsuite timestamp="yesterday"><testcase name="a"/></testsuite>`},
+		{"invalid time", `<testsuite><testcase name="a" time="long"/></testsuite>`},
+		{"invalid XML", `<testsuite><testcase name="a"></testsuite>`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseJUnitReport(strings.NewReader(test.report))
+			if err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// QMReportParser parses the report of a test framework
+type QMReportParser interface {
+	Parse(r io.Reader) (*QMTestReport, error)
+}
+
+// QMReportParserFunc is a function that implements QMReportParser
+type QMReportParserFunc func(r io.Reader) (*QMTestReport, error)
+
+// Parse report with the function
+func (f QMReportParserFunc) Parse(r io.Reader) (*QMTestReport, error) {
+	return f(r)
+}
+
+// QMReportParsers contains the available parsers by report format
+var QMReportParsers = map[string]QMReportParser{
+	"junit":  QMReportParserFunc(ParseJUnitReport),
+	"testng": QMReportParserFunc(ParseTestNGReport),
+	"xunit":  QMReportParserFunc(ParseXUnitReport),
+	"gotest": QMReportParserFunc(ParseGoTestReport),
+	"tap":    QMReportParserFunc(ParseTAPReport),
+}
+
+// QMReportParserFor returns the parser of the given report format
+func QMReportParserFor(format string) (QMReportParser, error) {
+	parser, ok := QMReportParsers[strings.ToLower(format)]
+	if !ok {
+		formats := make([]string, 0, len(QMReportParsers))
+		for key := range QMReportParsers {
+			formats = append(formats, key)
+		}
+		sort.Strings(formats)
+		return nil, fmt.Errorf("unknown report format \"%s\" (supported: %s)",
+			format, strings.Join(formats, ", "))
+	}
+	return parser, nil
+}
+
+// QMReportState maps the status of a test in a report to a QMResultState*
+// constant (e.g. "PASS" -> QMResultStatePassed). Unknown states are mapped to
+// QMResultStateInconclusive.
+func QMReportState(status string) string {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "pass", "passed", "ok", "success":
+		return QMResultStatePassed
+	case "fail", "failed", "failure", "not ok":
+		return QMResultStateFailed
+	case "error", "errored":
+		return QMResultStateError
+	case "skip", "skipped", "ignored", "disabled":
+		return QMResultStateDeferred
+	case "notrun", "not run", "not_run":
+		return QMResultStateNotRun
+	case "blocked":
+		return QMResultStateBlocked
+	case "inconclusive", "todo":
+		return QMResultStateInconclusive
+	}
+	return QMResultStateInconclusive
+}
+
+// ImportReport parses the report with the given parser and creates the
+// execution results
+func (i *QMImporter) ImportReport(ctx context.Context, parser QMReportParser, r io.Reader) (*QMImportResult, error) {
+	report, err := parser.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return i.Import(ctx, report)
+}
+
+// xmlRootElement returns the first start element of the decoder
+func xmlRootElement(decoder *xml.Decoder) (xml.StartElement, error) {
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start, nil
+		}
+	}
+}
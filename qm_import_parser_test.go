@@ -0,0 +1,68 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"testing"
+)
+
+func TestQMReportState(t *testing.T) {
+	tests := []struct {
+		status string
+		state  string
+	}{
+		{"PASS", QMResultStatePassed},
+		{"passed", QMResultStatePassed},
+		{"ok", QMResultStatePassed},
+		{"Success", QMResultStatePassed},
+		{"FAIL", QMResultStateFailed},
+		{"failure", QMResultStateFailed},
+		{"not ok", QMResultStateFailed},
+		{" error ", QMResultStateError},
+		{"errored", QMResultStateError},
+		{"SKIP", QMResultStateDeferred},
+		{"ignored", QMResultStateDeferred},
+		{"disabled", QMResultStateDeferred},
+		{"NotRun", QMResultStateNotRun},
+		{"not_run", QMResultStateNotRun},
+		{"blocked", QMResultStateBlocked},
+		{"todo", QMResultStateInconclusive},
+		{"something", QMResultStateInconclusive},
+		{"", QMResultStateInconclusive},
+	}
+
+	for _, test := range tests {
+		t.Run(test.status, func(t *testing.T) {
+			state := QMReportState(test.status)
+			if state != test.state {
+				t.Errorf("got state %s, expected %s", state, test.state)
+			}
+		})
+	}
+}
+
+func TestQMReportParserFor(t *testing.T) {
+	for _, format := range []string{"junit", "TestNG", "xunit", "gotest", "TAP"} {
+		parser, err := QMReportParserFor(format)
+		if err != nil || parser == nil {
+			t.Errorf("expected parser for %s, got %v", format, err)
+		}
+	}
+
+	_, err := QMReportParserFor("nunit")
+	if err == nil || err.Error() != "unknown report format \"nunit\" (supported: gotest, junit, tap, testng, xunit)" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
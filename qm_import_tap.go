@@ -0,0 +1,114 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// tapTestLine matches a test line of TAP
+// (e.g. "not ok 2 - description # TODO reason")
+var tapTestLine = regexp.MustCompile(`^(not )?ok\b\s*(\d+)?\s*(?:-\s*)?([^#]*?)\s*(?:#\s*(\w+)\s*(.*))?$`)
+
+// ParseTAPReport parses a report in the Test Anything Protocol. Tests with a
+// SKIP directive are reported as skipped and tests with a TODO directive as
+// inconclusive. YAML blocks and diagnostics following a test are added as
+// details. Indented lines of subtests are ignored.
+func ParseTAPReport(r io.Reader) (*QMTestReport, error) {
+	report := &QMTestReport{
+		Cases: make([]QMTestReportCase, 0),
+	}
+
+	var details []string
+	inYaml := false
+	finishCase := func() {
+		if len(report.Cases) > 0 && len(details) > 0 {
+			report.Cases[len(report.Cases)-1].Details = strings.Join(details, "\n")
+		}
+		details = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// YAML block of the previous test
+		if inYaml {
+			if strings.TrimSpace(line) == "..." {
+				inYaml = false
+			} else {
+				details = append(details, strings.TrimPrefix(line, "  "))
+			}
+			continue
+		}
+		if strings.TrimSpace(line) == "---" && len(report.Cases) > 0 {
+			inYaml = true
+			continue
+		}
+
+		// ignore subtests
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "Bail out!") {
+			break
+		}
+
+		if strings.HasPrefix(line, "#") {
+			if len(report.Cases) > 0 {
+				details = append(details, strings.TrimSpace(strings.TrimPrefix(line, "#")))
+			}
+			continue
+		}
+
+		match := tapTestLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		finishCase()
+
+		reportCase := QMTestReportCase{
+			Name:  match[3],
+			State: QMReportState("ok"),
+		}
+		if match[1] != "" {
+			reportCase.State = QMReportState("not ok")
+		}
+		if reportCase.Name == "" {
+			reportCase.Name = fmt.Sprintf("test %s", match[2])
+		}
+
+		switch strings.ToUpper(match[4]) {
+		case "SKIP":
+			reportCase.State = QMReportState("skip")
+			reportCase.Message = match[5]
+		case "TODO":
+			reportCase.State = QMReportState("todo")
+			reportCase.Message = match[5]
+		}
+		report.Cases = append(report.Cases, reportCase)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse TAP report: %w", err)
+	}
+	finishCase()
+
+	return report, nil
+}
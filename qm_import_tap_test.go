@@ -0,0 +1,72 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTAPReport(t *testing.T) {
+	report, err := ParseTAPReport(strings.NewReader(`TAP version 13
+1..6
+ok 1 - passed
+not ok 2 - failed
+  ---
+  message: wrong
+  at: test.js:10
+  ...
+ok 3 - skipped # SKIP not supported
+not ok 4 - todo # TODO later
+# diagnostic of todo
+ok 5
+    ok 1 - subtest is ignored
+ok 6 - passed again
+Bail out! database down
+ok 7 - after bail out
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []QMTestReportCase{
+		{Name: "passed", State: QMResultStatePassed},
+		{Name: "failed", State: QMResultStateFailed, Details: "message: wrong\nat: test.js:10"},
+		{Name: "skipped", State: QMResultStateDeferred, Message: "not supported"},
+		{Name: "todo", State: QMResultStateInconclusive, Message: "later", Details: "diagnostic of todo"},
+		{Name: "test 5", State: QMResultStatePassed},
+		{Name: "passed again", State: QMResultStatePassed},
+	}
+
+	if len(report.Cases) != len(expected) {
+		t.Fatalf("got %d cases, expected %d: %+v", len(report.Cases), len(expected), report.Cases)
+	}
+	for i, reportCase := range report.Cases {
+		if !reflect.DeepEqual(reportCase, expected[i]) {
+			t.Errorf("case %d:\ngot      %+v\nexpected %+v", i, reportCase, expected[i])
+		}
+	}
+}
+
+func TestParseTAPReportEmpty(t *testing.T) {
+	report, err := ParseTAPReport(strings.NewReader("1..0 # skipped\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Cases) != 0 {
+		t.Errorf("expected no cases, got %+v", report.Cases)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"regexp"
+	"testing"
+)
+
+// qmTestImporter creates an importer with already loaded test cases
+func qmTestImporter() *QMImporter {
+	importer := NewQMImporter(&QMTestPlan{})
+	importer.testCases = []*QMTestCase{
+		{QMBaseObject: QMBaseObject{ResourceUrl: "url/1"}, Title: "pkg.Class.first", WebId: 11},
+		{QMBaseObject: QMBaseObject{ResourceUrl: "url/2"}, Title: "second", WebId: 12},
+		{QMBaseObject: QMBaseObject{ResourceUrl: "url/3"}, Title: "third", WebId: 13},
+	}
+	return importer
+}
+
+func TestQMImporterTestCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		match    QMImportMatch
+		mapping  map[string]string
+		testCase QMTestReportCase
+		url      string
+	}{
+		{
+			name:     "title by full name",
+			testCase: QMTestReportCase{Suite: "pkg.Class", Name: "first"},
+			url:      "url/1",
+		},
+		{
+			name:     "title by name",
+			testCase: QMTestReportCase{Suite: "pkg.Class", Name: "second"},
+			url:      "url/2",
+		},
+		{
+			name:     "title not found",
+			testCase: QMTestReportCase{Suite: "pkg.Class", Name: "unknown"},
+		},
+		{
+			name:     "web ID",
+			match:    QMImportMatchWebId,
+			testCase: QMTestReportCase{Suite: "pkg.Class", Name: "TestCase13"},
+			url:      "url/3",
+		},
+		{
+			name:     "web ID without number",
+			match:    QMImportMatchWebId,
+			testCase: QMTestReportCase{Name: "second"},
+		},
+		{
+			name:     "mapping to title",
+			mapping:  map[string]string{"pkg.Class.test": "third"},
+			testCase: QMTestReportCase{Suite: "pkg.Class", Name: "test"},
+			url:      "url/3",
+		},
+		{
+			name:     "mapping to web ID",
+			match:    QMImportMatchWebId,
+			mapping:  map[string]string{"test": "11"},
+			testCase: QMTestReportCase{Suite: "pkg.Class", Name: "test"},
+			url:      "url/1",
+		},
+		{
+			name:     "mapping to resource URL",
+			mapping:  map[string]string{"second": "url/1"},
+			testCase: QMTestReportCase{Name: "second"},
+			url:      "url/1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			importer := qmTestImporter()
+			if test.match != "" {
+				importer.Match = test.match
+			}
+			importer.Mapping = test.mapping
+
+			testCase, err := importer.testCase(context.Background(), test.testCase)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var url string
+			if testCase != nil {
+				url = testCase.ResourceUrl
+			}
+			if url != test.url {
+				t.Errorf("got test case \"%s\", expected \"%s\"", url, test.url)
+			}
+		})
+	}
+}
+
+func TestQMImporterTestCaseErrors(t *testing.T) {
+	importer := qmTestImporter()
+	importer.Match = "unknown"
+	_, err := importer.testCase(context.Background(), QMTestReportCase{Name: "second"})
+	if err == nil {
+		t.Error("expected error for unknown match")
+	}
+
+	importer = qmTestImporter()
+	importer.Match = QMImportMatchWebId
+	importer.WebIdPattern = nil
+	_, err = importer.testCase(context.Background(), QMTestReportCase{Name: "11"})
+	if err == nil {
+		t.Error("expected error without web ID pattern")
+	}
+}
+
+func TestQMImporterWebIdPattern(t *testing.T) {
+	importer := qmTestImporter()
+	importer.Match = QMImportMatchWebId
+	importer.WebIdPattern = regexp.MustCompile(`TC-(\d+)`)
+
+	testCase, err := importer.testCase(context.Background(), QMTestReportCase{Name: "test1 TC-12"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testCase == nil || testCase.ResourceUrl != "url/2" {
+		t.Errorf("expected test case url/2, got %v", testCase)
+	}
+}
+
+func TestQMImporterUnmapped(t *testing.T) {
+	importer := qmTestImporter()
+	result, err := importer.Import(context.Background(), &QMTestReport{
+		Cases: []QMTestReportCase{{Name: "unknown"}, {Suite: "other", Name: "first"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Results) != 0 || len(result.Unmapped) != 2 {
+		t.Errorf("expected 2 unmapped tests, got %d results and %d unmapped",
+			len(result.Results), len(result.Unmapped))
+	}
+
+	_, err = (&QMImporter{}).Import(context.Background(), &QMTestReport{})
+	if err == nil {
+		t.Error("expected error without test plan")
+	}
+}
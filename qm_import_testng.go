@@ -0,0 +1,113 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// testNGResults is the root of a TestNG XML report
+type testNGResults struct {
+	Suites []struct {
+		Tests []struct {
+			Classes []struct {
+				Name    string         `xml:"name,attr"`
+				Methods []testNGMethod `xml:"test-method"`
+			} `xml:"class"`
+		} `xml:"test"`
+	} `xml:"suite"`
+}
+
+// testNGMethod of a TestNG XML report
+type testNGMethod struct {
+	Name       string `xml:"name,attr"`
+	Status     string `xml:"status,attr"`
+	IsConfig   bool   `xml:"is-config,attr"`
+	DurationMs int64  `xml:"duration-ms,attr"`
+	StartedAt  string `xml:"started-at,attr"`
+	Exception  *struct {
+		Class      string `xml:"class,attr"`
+		Message    string `xml:"message"`
+		StackTrace string `xml:"full-stacktrace"`
+	} `xml:"exception"`
+	Output []string `xml:"reporter-output>line"`
+}
+
+// ParseTestNGReport parses a TestNG XML report (testng-results.xml).
+// Configuration methods (e.g. @BeforeClass) are ignored.
+func ParseTestNGReport(r io.Reader) (*QMTestReport, error) {
+	var results testNGResults
+	err := xml.NewDecoder(r).Decode(&results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse TestNG report: %w", err)
+	}
+
+	report := &QMTestReport{
+		Cases: make([]QMTestReportCase, 0),
+	}
+	for _, suite := range results.Suites {
+		for _, test := range suite.Tests {
+			for _, class := range test.Classes {
+				for _, method := range class.Methods {
+					if method.IsConfig {
+						continue
+					}
+
+					startTime, err := parseTestNGTime(method.StartedAt)
+					if err != nil {
+						return nil, fmt.Errorf("failed to parse TestNG report: %w", err)
+					}
+
+					reportCase := QMTestReportCase{
+						Suite:     class.Name,
+						Name:      method.Name,
+						State:     QMReportState(method.Status),
+						StartTime: startTime,
+						Duration:  time.Duration(method.DurationMs) * time.Millisecond,
+						Stdout:    strings.TrimSpace(strings.Join(method.Output, "\n")),
+					}
+					if method.Exception != nil {
+						reportCase.Message = strings.TrimSpace(method.Exception.Message)
+						if reportCase.Message == "" {
+							reportCase.Message = method.Exception.Class
+						}
+						reportCase.Details = strings.TrimSpace(method.Exception.StackTrace)
+					}
+					report.Cases = append(report.Cases, reportCase)
+				}
+			}
+		}
+	}
+	return report, nil
+}
+
+// parseTestNGTime of a test method (e.g. "2022-01-02T03:04:05Z" or
+// "2022-01-02T03:04:05 CET")
+func parseTestNGTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05 MST"} {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time \"%s\"", s)
+}
@@ -0,0 +1,116 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTestNGReport(t *testing.T) {
+	report, err := ParseTestNGReport(strings.NewReader(`<?xml version="1.0" encoding="UTF-8"?>
+<testng-results skipped="1" failed="1" total="3" passed="1">
+  <suite name="suite">
+    <test name="test">
+      <class name="pkg.Class">
+        <test-method status="PASS" name="setUp" is-config="true" duration-ms="5"
+          started-at="2022-03-01T12:00:00Z"/>
+        <test-method status="PASS" name="passed" duration-ms="1500"
+          started-at="2022-03-01T12:00:00Z">
+          <reporter-output>
+            <line>first</line>
+            <line>second</line>
+          </reporter-output>
+        </test-method>
+        <test-method status="FAIL" name="failed" duration-ms="10"
+          started-at="2022-03-01T12:00:02 UTC">
+          <exception class="java.lang.AssertionError">
+            <message>expected 1</message>
+            <full-stacktrace>stack trace</full-stacktrace>
+          </exception>
+        </test-method>
+        <test-method status="SKIP" name="skipped">
+          <exception class="org.testng.SkipException"/>
+        </test-method>
+      </class>
+    </test>
+  </suite>
+</testng-results>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	expected := []QMTestReportCase{
+		{
+			Suite:     "pkg.Class",
+			Name:      "passed",
+			State:     QMResultStatePassed,
+			StartTime: start,
+			Duration:  1500 * time.Millisecond,
+			Stdout:    "first\nsecond",
+		},
+		{
+			Suite:     "pkg.Class",
+			Name:      "failed",
+			State:     QMResultStateFailed,
+			StartTime: start.Add(2 * time.Second),
+			Duration:  10 * time.Millisecond,
+			Message:   "expected 1",
+			Details:   "stack trace",
+		},
+		{
+			Suite:   "pkg.Class",
+			Name:    "skipped",
+			State:   QMResultStateDeferred,
+			Message: "org.testng.SkipException",
+		},
+	}
+
+	if len(report.Cases) != len(expected) {
+		t.Fatalf("got %d cases, expected %d", len(report.Cases), len(expected))
+	}
+	for i, reportCase := range report.Cases {
+		if !reportCase.StartTime.Equal(expected[i].StartTime) {
+			t.Errorf("case %d: got start time %s, expected %s", i, reportCase.StartTime, expected[i].StartTime)
+		}
+		reportCase.StartTime = expected[i].StartTime
+		if !reflect.DeepEqual(reportCase, expected[i]) {
+			t.Errorf("case %d:\ngot      %+v\nexpected %+v", i, reportCase, expected[i])
+		}
+	}
+}
+
+func TestParseTestNGReportErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		report string
+	}{
+		{"empty", ""},
+		{"invalid time", `<testng-results><suite><test><class name="a">
+<test-method name="b" status="PASS" started-at="yesterday"/></class></test></suite></testng-results>`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseTestNGReport(strings.NewReader(test.report))
+			if err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
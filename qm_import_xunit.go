@@ -0,0 +1,134 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// xUnitAssembly of a xUnit.net v2 XML report
+type xUnitAssembly struct {
+	RunDate     string `xml:"run-date,attr"`
+	RunTime     string `xml:"run-time,attr"`
+	Collections []struct {
+		Tests []xUnitTest `xml:"test"`
+	} `xml:"collection"`
+}
+
+// xUnitTest of a xUnit.net v2 XML report
+type xUnitTest struct {
+	Name    string `xml:"name,attr"`
+	Type    string `xml:"type,attr"`
+	Method  string `xml:"method,attr"`
+	Time    string `xml:"time,attr"`
+	Result  string `xml:"result,attr"`
+	Failure *struct {
+		ExceptionType string `xml:"exception-type,attr"`
+		Message       string `xml:"message"`
+		StackTrace    string `xml:"stack-trace"`
+	} `xml:"failure"`
+	Reason string          `xml:"reason"`
+	Output string          `xml:"output"`
+	Traits []junitProperty `xml:"traits>trait"`
+}
+
+// ParseXUnitReport parses a xUnit.net v2 XML report (with "assemblies" or
+// "assembly" as root element)
+func ParseXUnitReport(r io.Reader) (*QMTestReport, error) {
+	decoder := xml.NewDecoder(r)
+	root, err := xmlRootElement(decoder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse xUnit report: %w", err)
+	}
+
+	var assemblies []xUnitAssembly
+	switch root.Name.Local {
+	case "assemblies":
+		var buffer struct {
+			Assemblies []xUnitAssembly `xml:"assembly"`
+		}
+		err = decoder.DecodeElement(&buffer, &root)
+		assemblies = buffer.Assemblies
+
+	case "assembly":
+		var assembly xUnitAssembly
+		err = decoder.DecodeElement(&assembly, &root)
+		assemblies = []xUnitAssembly{assembly}
+
+	default:
+		err = fmt.Errorf("unexpected root element \"%s\"", root.Name.Local)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse xUnit report: %w", err)
+	}
+
+	report := &QMTestReport{
+		Cases: make([]QMTestReportCase, 0),
+	}
+	for _, assembly := range assemblies {
+		var startTime time.Time
+		if assembly.RunDate != "" && assembly.RunTime != "" {
+			startTime, err = time.Parse("2006-01-02 15:04:05",
+				assembly.RunDate+" "+assembly.RunTime)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse xUnit report: invalid run time \"%s %s\"",
+					assembly.RunDate, assembly.RunTime)
+			}
+		}
+
+		for _, collection := range assembly.Collections {
+			for _, test := range collection.Tests {
+				duration, err := parseReportSeconds(test.Time)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse xUnit report: %w", err)
+				}
+
+				reportCase := QMTestReportCase{
+					Suite:      test.Type,
+					Name:       test.Method,
+					State:      QMReportState(test.Result),
+					StartTime:  startTime,
+					Duration:   duration,
+					Stdout:     strings.TrimSpace(test.Output),
+					Message:    strings.TrimSpace(test.Reason),
+					Properties: make(map[string]string, len(test.Traits)),
+				}
+				if reportCase.Name == "" {
+					reportCase.Name = test.Name
+				}
+				if test.Failure != nil {
+					reportCase.Message = strings.TrimSpace(test.Failure.Message)
+					if reportCase.Message == "" {
+						reportCase.Message = test.Failure.ExceptionType
+					}
+					reportCase.Details = strings.TrimSpace(test.Failure.StackTrace)
+				}
+				for _, trait := range test.Traits {
+					reportCase.Properties[trait.Name] = trait.Value
+				}
+				report.Cases = append(report.Cases, reportCase)
+
+				if !startTime.IsZero() {
+					startTime = startTime.Add(duration)
+				}
+			}
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseXUnitReport(t *testing.T) {
+	report, err := ParseXUnitReport(strings.NewReader(`<?xml version="1.0" encoding="utf-8"?>
+<assemblies>
+  <assembly name="Tests.dll" run-date="2022-03-01" run-time="12:00:00">
+    <collection name="collection">
+      <test name="Tests.Class.Passed" type="Tests.Class" method="Passed" time="1.5" result="Pass">
+        <output>output</output>
+        <traits>
+          <trait name="Category" value="fast"/>
+        </traits>
+      </test>
+      <test name="Tests.Class.Failed" type="Tests.Class" method="Failed" time="0.25" result="Fail">
+        <failure exception-type="Xunit.Sdk.EqualException">
+          <message>Assert.Equal() Failure</message>
+          <stack-trace>stack trace</stack-trace>
+        </failure>
+      </test>
+      <test name="Tests.Class.Skipped" type="Tests.Class" time="0" result="Skip">
+        <reason>not supported</reason>
+      </test>
+    </collection>
+  </assembly>
+</assemblies>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	expected := []QMTestReportCase{
+		{
+			Suite:      "Tests.Class",
+			Name:       "Passed",
+			State:      QMResultStatePassed,
+			StartTime:  start,
+			Duration:   1500 * time.Millisecond,
+			Stdout:     "output",
+			Properties: map[string]string{"Category": "fast"},
+		},
+		{
+			Suite:      "Tests.Class",
+			Name:       "Failed",
+			State:      QMResultStateFailed,
+			StartTime:  start.Add(1500 * time.Millisecond),
+			Duration:   250 * time.Millisecond,
+			Message:    "Assert.Equal() Failure",
+			Details:    "stack trace",
+			Properties: map[string]string{},
+		},
+		{
+			Suite:      "Tests.Class",
+			Name:       "Tests.Class.Skipped",
+			State:      QMResultStateDeferred,
+			StartTime:  start.Add(1750 * time.Millisecond),
+			Message:    "not supported",
+			Properties: map[string]string{},
+		},
+	}
+
+	if len(report.Cases) != len(expected) {
+		t.Fatalf("got %d cases, expected %d", len(report.Cases), len(expected))
+	}
+	for i, reportCase := range report.Cases {
+		if !reflect.DeepEqual(reportCase, expected[i]) {
+			t.Errorf("case %d:\ngot      %+v\nexpected %+v", i, reportCase, expected[i])
+		}
+	}
+}
+
+func TestParseXUnitReportSingleAssembly(t *testing.T) {
+	report, err := ParseXUnitReport(strings.NewReader(`<assembly>
+  <collection><test type="A" method="B" result="Pass"/></collection>
+</assembly>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Cases) != 1 || report.Cases[0].FullName() != "A.B" || !report.Cases[0].StartTime.IsZero() {
+		t.Errorf("unexpected cases %+v", report.Cases)
+	}
+}
+
+func TestParseXUnitReportErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		report string
+	}{
+		{"empty", ""},
+		{"unknown root", "<report/>"},
+		{"invalid run time", `<assembly run-date="2022-03-01" run-time="noon"/>`},
+		{"invalid time", `<assembly><collection><test method="a" time="long"/></collection></assembly>`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseXUnitReport(strings.NewReader(test.report))
+			if err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -17,6 +17,11 @@ package jazz
 import (
 	"encoding/json"
 	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -35,12 +40,20 @@ const (
 	QMResultStateBlocked      = "com.ibm.rqm.execution.common.state.blocked"
 )
 
+const (
+	QMStepTypeExecution    = "execution"
+	QMStepTypeVerification = "verification"
+	QMStepTypeReporting    = "reporting"
+)
+
 // QMObject describes a QM object implementation
 type QMObject interface {
 	Spec() *QMObjectSpec
 	SetProj(proj *QMProject)
 	Ref() QMRef
 	SetRef(url string)
+	ETag() string
+	SetETag(etag string)
 }
 
 // QMBaseObject for RQM resources
@@ -50,6 +63,12 @@ type QMBaseObject struct {
 
 	// QMProject instance used for interactions with the server
 	proj *QMProject
+
+	// etag of the loaded object version
+	etag string
+
+	// raw XML document of the loaded object version (used by QMUpdate)
+	raw []byte
 }
 
 // SetProj of object
@@ -58,7 +77,7 @@ func (o *QMBaseObject) SetProj(proj *QMProject) {
 }
 
 // Ref returns QMRef of object
-func (o QMBaseObject) Ref() QMRef {
+func (o *QMBaseObject) Ref() QMRef {
 	return QMRef{
 		Href: o.ResourceUrl,
 	}
@@ -69,6 +88,32 @@ func (o *QMBaseObject) SetRef(url string) {
 	o.ResourceUrl = url
 }
 
+// ETag of the object version loaded from the server
+func (o *QMBaseObject) ETag() string {
+	return o.etag
+}
+
+// SetETag of the object version (used for conditional updates)
+func (o *QMBaseObject) SetETag(etag string) {
+	o.etag = etag
+}
+
+// rawXml of the object loaded from the server
+func (o *QMBaseObject) rawXml() []byte {
+	return o.raw
+}
+
+// setRawXml of the object loaded from the server
+func (o *QMBaseObject) setRawXml(raw []byte) {
+	o.raw = raw
+}
+
+// qmRawObject is implemented by objects that keep the loaded XML document
+type qmRawObject interface {
+	rawXml() []byte
+	setRawXml(raw []byte)
+}
+
 // QMRef reference to object
 type QMRef struct {
 	Href string `json:"href" xml:"href,attr"`
@@ -95,6 +140,51 @@ type QMCategory struct {
 	Value string `xml:"value,attr"`
 }
 
+// QMTestStep of a manual test script
+type QMTestStep struct {
+	// Index of step (starts with 1)
+	Index int `xml:"stepIndex,attr"`
+
+	// Type of step (see QMStepType*)
+	Type string `xml:"type,attr"`
+
+	// Name of step
+	Name string `xml:"name"`
+
+	// Description of step (XHTML)
+	Description QMXmlText `xml:"description"`
+
+	// ExpectedResult of step (XHTML)
+	ExpectedResult QMXmlText `xml:"expectedResult"`
+
+	// AttachmentRefs contains list of resource URLs for QMAttachment
+	AttachmentRefs QMRefList `xml:"attachment"`
+
+	// RequirementRefs contains list of URLs of linked requirements
+	RequirementRefs QMRefList `xml:"link"`
+}
+
+// QMStepResult of a single step in a QMTestExecutionResult
+type QMStepResult struct {
+	// Index of executed step (starts with 1)
+	Index int `xml:"stepIndex,attr"`
+
+	// Result of step (see QMResultState*)
+	Result string `xml:"result,attr"`
+
+	// Description of step (XHTML)
+	Description QMXmlText `xml:"description"`
+
+	// ExpectedResult of step (XHTML)
+	ExpectedResult QMXmlText `xml:"expectedResult"`
+
+	// ActualResult of step (XHTML)
+	ActualResult QMXmlText `xml:"actualResult"`
+
+	// Comment of tester (XHTML)
+	Comment QMXmlText `xml:"comment"`
+}
+
 // QMDuration used in QM objects (stored as milliseconds)
 type QMDuration time.Duration
 
@@ -112,6 +202,11 @@ func (d *QMDuration) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement)
 	return nil
 }
 
+// MarshalXML writes the duration as milliseconds
+func (d QMDuration) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	return encoder.EncodeElement(time.Duration(d).Milliseconds(), start)
+}
+
 // QMVariableMap contains list of variables
 type QMVariableMap map[string]string
 
@@ -152,6 +247,36 @@ func (m *QMVariableMap) UnmarshalXML(decoder *xml.Decoder, start xml.StartElemen
 	return nil
 }
 
+// MarshalXML writes the variables sorted by name in the namespace of the
+// variables element
+func (m QMVariableMap) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	type variable struct {
+		Name  string `xml:"name"`
+		Value string `xml:"value"`
+	}
+
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	err := encoder.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		err = encoder.EncodeElement(variable{
+			Name:  name,
+			Value: m[name],
+		}, xml.StartElement{Name: xml.Name{Space: start.Name.Space, Local: "variable"}})
+		if err != nil {
+			return err
+		}
+	}
+	return encoder.EncodeToken(start.End())
+}
+
 // QMXmlText is a custom type to handle XML text content
 type QMXmlText string
 
@@ -168,3 +293,87 @@ func (t *QMXmlText) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) e
 
 	return nil
 }
+
+// qmXhtmlNamespace used for rich text elements without namespace
+const qmXhtmlNamespace = "http://www.w3.org/1999/xhtml"
+
+// MarshalXML writes the text as XML content of the element. The text is
+// parsed (e.g. XHTML of rich text fields) and written again with the
+// prefixes as they are. Top level elements without namespace are written in
+// the XHTML namespace. Text that is no valid XML (e.g. "a & b") is written
+// as escaped character data.
+func (t QMXmlText) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	tokens, err := t.tokens()
+	if err != nil {
+		return encoder.EncodeElement(string(t), start)
+	}
+
+	err = encoder.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		err = encoder.EncodeToken(token)
+		if err != nil {
+			return err
+		}
+	}
+	return encoder.EncodeToken(start.End())
+}
+
+// tokens parses the text as XML content and returns the tokens to write
+func (t QMXmlText) tokens() ([]xml.Token, error) {
+	var tokens []xml.Token
+	var open []xml.Name
+	decoder := xml.NewDecoder(strings.NewReader(string(t)))
+	for {
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch tok := token.(type) {
+		case xml.StartElement:
+			attrs := make([]xml.Attr, 0, len(tok.Attr)+1)
+			defaultNamespace := false
+			for _, attr := range tok.Attr {
+				attr.Name = rawXmlName(attr.Name)
+				defaultNamespace = defaultNamespace || attr.Name.Local == "xmlns"
+				attrs = append(attrs, attr)
+			}
+			if len(open) == 0 && tok.Name.Space == "" && !defaultNamespace {
+				attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: qmXhtmlNamespace})
+			}
+			token = xml.StartElement{Name: rawXmlName(tok.Name), Attr: attrs}
+			open = append(open, rawXmlName(tok.Name))
+		case xml.EndElement:
+			name := rawXmlName(tok.Name)
+			if len(open) == 0 || open[len(open)-1] != name {
+				return nil, fmt.Errorf("unexpected end element </%s>", name.Local)
+			}
+			open = open[:len(open)-1]
+			token = xml.EndElement{Name: name}
+		case xml.ProcInst, xml.Directive:
+			// not allowed inside of elements
+			continue
+		default:
+			token = xml.CopyToken(token)
+		}
+		tokens = append(tokens, token)
+	}
+	if len(open) != 0 {
+		return nil, errors.New("unclosed element")
+	}
+	return tokens, nil
+}
+
+// rawXmlName joins prefix and local name of a raw token
+func rawXmlName(name xml.Name) xml.Name {
+	if name.Space == "" {
+		return name
+	}
+	return xml.Name{Local: name.Space + ":" + name.Local}
+}
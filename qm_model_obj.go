@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 	"time"
 )
 
@@ -167,6 +168,9 @@ type QMManualTestScript struct {
 
 	// Updated contains last update time
 	Updated time.Time `xml:"updated"`
+
+	// Steps of test script
+	Steps []QMTestStep `xml:"steps>step"`
 }
 
 // Spec returns the specification object for QMManualTestScript
@@ -241,6 +245,9 @@ type QMTestExecutionRecord struct {
 	// Updated contains last update time
 	Updated time.Time `xml:"updated"`
 
+	// TestPlanRef contains reference to QMTestPlan
+	TestPlanRef QMRef `xml:"testplan"`
+
 	// TestCaseRef contains reference to last execution QMTestCase
 	TestCaseRef QMRef `xml:"testcase"`
 
@@ -261,6 +268,11 @@ func (o *QMTestExecutionRecord) Spec() *QMObjectSpec {
 	}
 }
 
+// TestPlan of this QMTestExecutionRecord
+func (o *QMTestExecutionRecord) TestPlan(ctx context.Context) (*QMTestPlan, error) {
+	return QMGet[*QMTestPlan](ctx, o.proj, o.TestPlanRef.Href)
+}
+
 // TestCase of this QMTestExecutionRecord
 func (o *QMTestExecutionRecord) TestCase(ctx context.Context) (*QMTestCase, error) {
 	return QMGet[*QMTestCase](ctx, o.proj, o.TestCaseRef.Href)
@@ -279,10 +291,10 @@ type QMTestExecutionResult struct {
 	Title string `xml:"title"`
 
 	// Numeric identifier shown in webinterface
-	WebId int `xml:"webId" jazz:"qm:webId"`
+	WebId int `xml:"webId"`
 
 	// State of test execution
-	State string `xml:"state" jazz:"alm:state"`
+	State string `xml:"state"`
 
 	// Creator of entry
 	Creator string `xml:"creator"`
@@ -291,37 +303,40 @@ type QMTestExecutionResult struct {
 	Updated time.Time `xml:"updated"`
 
 	// Machine of where test was executed
-	Machine string `xml:"machine" jazz:"qmresult:machine"`
+	Machine string `xml:"machine"`
 
 	// StartTime of test execution
-	StartTime time.Time `xml:"starttime" jazz:"qmresult:starttime"`
+	StartTime time.Time `xml:"starttime"`
 
 	// EndTime of test execution
-	EndTime time.Time `xml:"endtime" jazz:"qmresult:endtime"`
+	EndTime time.Time `xml:"endtime"`
 
 	// Variables of test execution result
-	Variables QMVariableMap `xml:"variables" jazz:"qm:variables"`
+	Variables QMVariableMap `xml:"variables"`
 
 	// TestPlanRef contains reference to last execution QMTestPlan
-	TestPlanRef QMRef `xml:"testplan" jazz:"qm:testplan"`
+	TestPlanRef QMRef `xml:"testplan"`
 
 	// TestCaseRef contains reference to last execution QMTestCase
-	TestCaseRef QMRef `xml:"testcase" jazz:"qm:testcase"`
+	TestCaseRef QMRef `xml:"testcase"`
 
 	// TestEnvironmentRef contains reference to last execution QMTestEnvironment
-	TestEnvironmentRef QMRef `xml:"configuration" jazz:"qm:configuration"`
+	TestEnvironmentRef QMRef `xml:"configuration"`
 
 	// TestExecutionRecordRef contains reference to last execution QMTestExecutionRecord
-	TestExecutionRecordRef QMRef `xml:"executionworkitem" jazz:"qm:executionworkitem"`
+	TestExecutionRecordRef QMRef `xml:"executionworkitem"`
 
 	// AutomaticTestScriptRef contains reference to last execution QMAutomaticTestScript
-	AutomaticTestScriptRef QMRef `xml:"remotescript" jazz:"qm:remotescript"`
+	AutomaticTestScriptRef QMRef `xml:"remotescript"`
 
 	// ManualTestScriptRef contains reference to last execution QMManualTestScript
-	ManualTestScriptRef QMRef `xml:"testscript" jazz:"qm:testscript"`
+	ManualTestScriptRef QMRef `xml:"testscript"`
 
 	// AttachmentRefs contains reference to last execution QMAttachment
-	AttachmentRefs QMRefList `xml:"attachment" jazz:"qm:attachment"`
+	AttachmentRefs QMRefList `xml:"attachment"`
+
+	// StepResults of a manual test script execution
+	StepResults []QMStepResult `xml:"stepResults>stepResult"`
 }
 
 // Spec returns the specification object for QMManualTestScript
@@ -382,6 +397,9 @@ type QMTestPlan struct {
 
 	// TestCaseRefs contains list of resource URLs for QMTestCase
 	TestCaseRefs QMRefList `xml:"testcase"`
+
+	// TestSuiteRefs contains list of resource URLs for QMTestSuite
+	TestSuiteRefs QMRefList `xml:"testsuite"`
 }
 
 // Spec returns the specification object for QMTestPlan
@@ -409,3 +427,234 @@ func (o *QMTestPlan) TestExecutionResults(ctx context.Context) ([]*QMTestExecuti
 		"testplan/@href": o.ResourceUrl,
 	})
 }
+
+// TestSuites that are part of this QMTestPlan
+func (o *QMTestPlan) TestSuites(ctx context.Context) ([]*QMTestSuite, error) {
+	return qmGetList[*QMTestSuite](ctx, o.proj, o.TestSuiteRefs.IDList())
+}
+
+// SuiteExecutionRecords that are part of this QMTestPlan
+func (o *QMTestPlan) SuiteExecutionRecords(ctx context.Context) ([]*QMSuiteExecutionRecord, error) {
+	return QMList[*QMSuiteExecutionRecord](ctx, o.proj, map[string]string{
+		"testplan/@href": o.ResourceUrl,
+	})
+}
+
+// QMSuiteElement is an entry of a QMTestSuite
+type QMSuiteElement struct {
+	// Index of element in test suite (execution order)
+	Index int `xml:"elementindex,attr"`
+
+	// TestCaseRef contains reference to QMTestCase
+	TestCaseRef QMRef `xml:"testcase"`
+
+	// TestEnvironmentRef contains reference to QMTestEnvironment
+	TestEnvironmentRef QMRef `xml:"configuration"`
+
+	// AutomaticTestScriptRef contains reference to QMAutomaticTestScript
+	AutomaticTestScriptRef QMRef `xml:"remotescript"`
+
+	// ManualTestScriptRef contains reference to QMManualTestScript
+	ManualTestScriptRef QMRef `xml:"testscript"`
+}
+
+// QMTestSuite implements the RQM "testsuite" resource
+type QMTestSuite struct {
+	QMBaseObject
+
+	// Title of object
+	Title string `xml:"title"`
+
+	// Numeric identifier shown in webinterface
+	WebId int `xml:"webId"`
+
+	// Description of object
+	Description string `xml:"description"`
+
+	// Owner of test suite
+	Owner string `xml:"owner"`
+
+	// Creator of test suite
+	Creator string `xml:"creator"`
+
+	// Updated contains last update time
+	Updated time.Time `xml:"updated"`
+
+	// estimated execution time
+	Estimate QMDuration `xml:"estimate"`
+
+	// Categories of test suite
+	Categories []QMCategory `xml:"category"`
+
+	// Elements of test suite (test cases with scripts)
+	Elements []QMSuiteElement `xml:"suiteelements>suiteelement"`
+}
+
+// Spec returns the specification object for QMTestSuite
+func (o *QMTestSuite) Spec() *QMObjectSpec {
+	return &QMObjectSpec{
+		ResourceID: "testsuite",
+	}
+}
+
+// SortedElements returns the elements of this QMTestSuite in execution order
+func (o *QMTestSuite) SortedElements() []QMSuiteElement {
+	elements := make([]QMSuiteElement, len(o.Elements))
+	copy(elements, o.Elements)
+	sort.SliceStable(elements, func(i, j int) bool {
+		return elements[i].Index < elements[j].Index
+	})
+	return elements
+}
+
+// TestCases that are part of this QMTestSuite in execution order
+func (o *QMTestSuite) TestCases(ctx context.Context) ([]*QMTestCase, error) {
+	elements := o.SortedElements()
+	ids := make([]string, 0, len(elements))
+	for _, element := range elements {
+		if element.TestCaseRef.Href != "" {
+			ids = append(ids, element.TestCaseRef.Href)
+		}
+	}
+	return qmGetOrderedList[*QMTestCase](ctx, o.proj, ids)
+}
+
+// SuiteExecutionRecords of this QMTestSuite
+func (o *QMTestSuite) SuiteExecutionRecords(ctx context.Context) ([]*QMSuiteExecutionRecord, error) {
+	return QMList[*QMSuiteExecutionRecord](ctx, o.proj, map[string]string{
+		"testsuite/@href": o.ResourceUrl,
+	})
+}
+
+// QMSuiteExecutionRecord implements the RQM "suiteexecutionrecord" resource
+type QMSuiteExecutionRecord struct {
+	QMBaseObject
+
+	// Title of object
+	Title string `xml:"title"`
+
+	// Numeric identifier shown in webinterface
+	WebId int `xml:"webId"`
+
+	// Description of object
+	Description string `xml:"description"`
+
+	// Owner of suite execution record
+	Owner string `xml:"owner"`
+
+	// Creator of suite execution record
+	Creator string `xml:"creator"`
+
+	// Updated contains last update time
+	Updated time.Time `xml:"updated"`
+
+	// TestSuiteRef contains reference to QMTestSuite
+	TestSuiteRef QMRef `xml:"testsuite"`
+
+	// TestPlanRef contains reference to QMTestPlan
+	TestPlanRef QMRef `xml:"testplan"`
+
+	// TestEnvironmentRef contains reference to QMTestEnvironment
+	TestEnvironmentRef QMRef `xml:"configuration"`
+
+	// LastTestSuiteLogRef contains reference to last execution QMTestSuiteLog
+	LastTestSuiteLogRef QMRef `xml:"currentsuiteexecutionlog"`
+}
+
+// Spec returns the specification object for QMSuiteExecutionRecord
+func (o *QMSuiteExecutionRecord) Spec() *QMObjectSpec {
+	return &QMObjectSpec{
+		ResourceID: "suiteexecutionrecord",
+	}
+}
+
+// TestSuite of this QMSuiteExecutionRecord
+func (o *QMSuiteExecutionRecord) TestSuite(ctx context.Context) (*QMTestSuite, error) {
+	return QMGet[*QMTestSuite](ctx, o.proj, o.TestSuiteRef.Href)
+}
+
+// TestPlan of this QMSuiteExecutionRecord
+func (o *QMSuiteExecutionRecord) TestPlan(ctx context.Context) (*QMTestPlan, error) {
+	return QMGet[*QMTestPlan](ctx, o.proj, o.TestPlanRef.Href)
+}
+
+// TestEnvironment of this QMSuiteExecutionRecord
+func (o *QMSuiteExecutionRecord) TestEnvironment(ctx context.Context) (*QMTestEnvironment, error) {
+	return QMGet[*QMTestEnvironment](ctx, o.proj, o.TestEnvironmentRef.Href)
+}
+
+// LastTestSuiteLog of this QMSuiteExecutionRecord
+func (o *QMSuiteExecutionRecord) LastTestSuiteLog(ctx context.Context) (*QMTestSuiteLog, error) {
+	return QMGet[*QMTestSuiteLog](ctx, o.proj, o.LastTestSuiteLogRef.Href)
+}
+
+// TestSuiteLogs of this QMSuiteExecutionRecord
+func (o *QMSuiteExecutionRecord) TestSuiteLogs(ctx context.Context) ([]*QMTestSuiteLog, error) {
+	return QMList[*QMTestSuiteLog](ctx, o.proj, map[string]string{
+		"suiteexecutionrecord/@href": o.ResourceUrl,
+	})
+}
+
+// QMTestSuiteLog implements the RQM "testsuitelog" resource
+// (WebUI Name: "Test Suite Result")
+type QMTestSuiteLog struct {
+	QMBaseObject
+
+	// Title of object
+	Title string `xml:"title"`
+
+	// Numeric identifier shown in webinterface
+	WebId int `xml:"webId"`
+
+	// State of test suite execution
+	State string `xml:"state"`
+
+	// Creator of entry
+	Creator string `xml:"creator"`
+
+	// Updated contains last update time
+	Updated time.Time `xml:"updated"`
+
+	// StartTime of test suite execution
+	StartTime time.Time `xml:"starttime"`
+
+	// EndTime of test suite execution
+	EndTime time.Time `xml:"endtime"`
+
+	// TestSuiteRef contains reference to QMTestSuite
+	TestSuiteRef QMRef `xml:"testsuite"`
+
+	// TestPlanRef contains reference to QMTestPlan
+	TestPlanRef QMRef `xml:"testplan"`
+
+	// TestEnvironmentRef contains reference to QMTestEnvironment
+	TestEnvironmentRef QMRef `xml:"configuration"`
+
+	// SuiteExecutionRecordRef contains reference to QMSuiteExecutionRecord
+	SuiteExecutionRecordRef QMRef `xml:"suiteexecutionrecord"`
+
+	// TestExecutionResultRefs contains list of resource URLs for QMTestExecutionResult
+	TestExecutionResultRefs QMRefList `xml:"executionresult"`
+}
+
+// Spec returns the specification object for QMTestSuiteLog
+func (o *QMTestSuiteLog) Spec() *QMObjectSpec {
+	return &QMObjectSpec{
+		ResourceID: "testsuitelog",
+	}
+}
+
+// TestSuite of this QMTestSuiteLog
+func (o *QMTestSuiteLog) TestSuite(ctx context.Context) (*QMTestSuite, error) {
+	return QMGet[*QMTestSuite](ctx, o.proj, o.TestSuiteRef.Href)
+}
+
+// SuiteExecutionRecord of this QMTestSuiteLog
+func (o *QMTestSuiteLog) SuiteExecutionRecord(ctx context.Context) (*QMSuiteExecutionRecord, error) {
+	return QMGet[*QMSuiteExecutionRecord](ctx, o.proj, o.SuiteExecutionRecordRef.Href)
+}
+
+// TestExecutionResults that are part of this QMTestSuiteLog
+func (o *QMTestSuiteLog) TestExecutionResults(ctx context.Context) ([]*QMTestExecutionResult, error) {
+	return qmGetOrderedList[*QMTestExecutionResult](ctx, o.proj, o.TestExecutionResultRefs.IDList())
+}
@@ -15,18 +15,39 @@
 package jazz
 
 import (
-	"bytes"
+	"encoding/xml"
 	"fmt"
 	"net/url"
-	"reflect"
 	"strconv"
 	"strings"
-	"time"
 )
 
 // QMFilter is used to filter results in QM list queries
 type QMFilter map[string]string
 
+// QMFilterAll matches all objects. QMBulk requires it instead of an empty
+// filter to execute an action for all objects of a type.
+var QMFilterAll = QMFilter{qmFilterAll: ""}
+
+// special key of QMFilterAll that is not used as field filter
+const qmFilterAll = "_all"
+
+// all returns true if the filter explicitly matches all objects
+func (f QMFilter) all() bool {
+	_, ok := f[qmFilterAll]
+	return ok
+}
+
+// empty returns true if the filter contains no field filter
+func (f QMFilter) empty() bool {
+	for key := range f {
+		if key != qmFilterAll {
+			return false
+		}
+	}
+	return true
+}
+
 type QMObjectSpec struct {
 	// Resource identifier of object.
 	// https://jazz.net/wiki/bin/view/Main/RqmApi#Resources_and_their_Supported_Op
@@ -46,12 +67,15 @@ func (o *QMObjectSpec) ListURL(proj *QMProject, filter QMFilter) (string, error)
 
 // buildFilterQuery for the given QMFilter
 func (o *QMObjectSpec) buildFilterQuery(filter QMFilter) (string, error) {
-	if len(filter) == 0 {
+	if filter.empty() {
 		return "", nil
 	}
 
 	var filterList []string
 	for key, value := range filter {
+		if key == qmFilterAll {
+			continue
+		}
 		filterList = append(filterList, fmt.Sprintf("%s='%s'", key, url.QueryEscape(value)))
 	}
 	return fmt.Sprintf("?fields=feed/entry/content/%s[%s]", o.ResourceID, strings.Join(filterList, " and ")), nil
@@ -77,81 +101,18 @@ func (o *QMObjectSpec) GetURL(proj *QMProject, id string) string {
 		proj.Alias, o.ResourceID, id)
 }
 
-// DumpXml for update or creation of objects
-func (o *QMObjectSpec) DumpXml(obj QMObject) []byte {
-	buffer := bytes.NewBuffer(nil)
-
-	// build xml namespace attributes
-	ns := map[string]string{
-		"qm":       "http://jazz.net/xmlns/alm/qm/v0.1/",
-		"alm":      "http://jazz.net/xmlns/alm/v0.1/",
-		"qmresult": "http://jazz.net/xmlns/alm/qm/v0.1/executionresult/v0.1",
-	}
-	var xmlns []string
-	for key, value := range ns {
-		xmlns = append(xmlns, fmt.Sprintf("xmlns:%s=\"%s\"", key, value))
+// DumpXml for update or creation of objects. Only the writable fields of the
+// object are written with the namespaces required by the server.
+func (o *QMObjectSpec) DumpXml(obj QMObject) ([]byte, error) {
+	writable, ok := obj.(qmWritable)
+	if !ok {
+		return nil, fmt.Errorf("failed to dump %s: object can not be written", o.ResourceID)
 	}
 
-	_, _ = fmt.Fprintf(buffer, "<qm:%s %s>\n",
-		o.ResourceID, strings.Join(xmlns, " "))
-
-	val := reflect.ValueOf(obj).Elem()
-	t := val.Type()
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		fieldValue := val.Field(i)
-
-		// only handle jazz fields
-		fieldName := field.Tag.Get("jazz")
-		if fieldName == "" {
-			continue
-		}
-
-		// add value to dump
-		switch v := fieldValue.Interface().(type) {
-		case QMRef:
-			if v.Href != "" {
-				_, _ = fmt.Fprintf(buffer, " <%s href=\"%s\"/>\n", fieldName, v.Href)
-			}
-		case QMRefList:
-			for _, ref := range v {
-				if ref.Href != "" {
-					_, _ = fmt.Fprintf(buffer, " <%s href=\"%s\"/>\n", fieldName, ref.Href)
-				}
-			}
-		case QMVariableMap:
-			_, _ = fmt.Fprintf(buffer, " <%s>\n", fieldName)
-			for key, value := range v {
-				_, _ = fmt.Fprintf(buffer,
-					"  <qm:variable><qm:name>%s</qm:name><qm:value>%s</qm:value></qm:variable>\n",
-					key, value)
-			}
-			_, _ = fmt.Fprintf(buffer, " </%s>\n", fieldName)
-		case string:
-			if len(v) > 0 {
-				_, _ = fmt.Fprintf(buffer, " <%s>%s</%s>\n", fieldName, v, fieldName)
-			}
-		case time.Time:
-			if !v.IsZero() {
-				_, _ = fmt.Fprintf(buffer, " <%s>%s</%s>\n", fieldName, v.Format(time.RFC3339), fieldName)
-			}
-
-		case fmt.Stringer:
-			str := v.String()
-			if len(str) > 0 {
-				_, _ = fmt.Fprintf(buffer, " <%s>%s</%s>\n", fieldName, str, fieldName)
-			}
-
-		case int:
-			if v != 0 {
-				_, _ = fmt.Fprintf(buffer, " <%s>%d</%s>\n", fieldName, v, fieldName)
-			}
-
-		default:
-			panic("unknown type")
-		}
+	// not indented as whitespace is part of rich text content
+	data, err := xml.Marshal(writable.writeXml())
+	if err != nil {
+		return nil, fmt.Errorf("failed to dump %s: %w", o.ResourceID, err)
 	}
-	_, _ = fmt.Fprintf(buffer, "</qm:%s>\n", o.ResourceID)
-
-	return buffer.Bytes()
+	return append(data, '\n'), nil
 }
@@ -0,0 +1,519 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+)
+
+// namespaces of QM documents used in the tests
+const (
+	qmTestNsQM         = "http://jazz.net/xmlns/alm/qm/v0.1/"
+	qmTestNsAlm        = "http://jazz.net/xmlns/alm/v0.1/"
+	qmTestNsDC         = "http://purl.org/dc/elements/1.1/"
+	qmTestNsTestScript = "http://jazz.net/xmlns/alm/qm/v0.1/testscript/v0.1/"
+	qmTestNsXhtml      = "http://www.w3.org/1999/xhtml"
+)
+
+// qmTestFixtureProject returns a project that serves the files of
+// testdata/qm by resource type (e.g. testdata/qm/testcase.xml)
+func qmTestFixtureProject(t *testing.T) *QMProject {
+	return qmTestProject(t, func(w http.ResponseWriter, r *http.Request) {
+		data, err := os.ReadFile(filepath.Join("testdata", "qm", path.Base(path.Dir(r.URL.Path))+".xml"))
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(data)
+	})
+}
+
+// qmTestDump dumps the object and returns the root element of the document
+func qmTestDump(t *testing.T, obj QMObject) *etree.Element {
+	data, err := obj.Spec().DumpXml(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc := etree.NewDocument()
+	err = doc.ReadFromBytes(data)
+	if err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, data)
+	}
+	if doc.Root() == nil {
+		t.Fatalf("empty document:\n%s", data)
+	}
+	return doc.Root()
+}
+
+// qmTestName of an element as "<namespace URI> <name>"
+func qmTestName(element *etree.Element) string {
+	return element.NamespaceURI() + " " + element.Tag
+}
+
+// qmTestChildNames of all child elements
+func qmTestChildNames(element *etree.Element) []string {
+	var names []string
+	for _, child := range element.ChildElements() {
+		names = append(names, qmTestName(child))
+	}
+	return names
+}
+
+// qmTestAttrNames of all attributes as "<namespace URI> <name>=<value>"
+// (namespace declarations are skipped)
+func qmTestAttrNames(element *etree.Element) []string {
+	var names []string
+	for _, attr := range element.Attr {
+		if attr.Space == "xmlns" || (attr.Space == "" && attr.Key == "xmlns") {
+			continue
+		}
+
+		// namespace of the prefix declared in the element or its parents
+		var namespace string
+		for e := element; attr.Space != "" && e != nil && namespace == ""; e = e.Parent() {
+			namespace = e.SelectAttrValue("xmlns:"+attr.Space, "")
+		}
+		names = append(names, namespace+" "+attr.Key+"="+attr.Value)
+	}
+	return names
+}
+
+func TestQMDumpXmlFixtures(t *testing.T) {
+	tests := []struct {
+		name     string
+		get      func(ctx context.Context, proj *QMProject, id string) (QMObject, error)
+		root     string
+		elements []string
+	}{
+		{
+			name: "testcase",
+			get: func(ctx context.Context, proj *QMProject, id string) (QMObject, error) {
+				return QMGet[*QMTestCase](ctx, proj, id)
+			},
+			root: qmTestNsQM + " testcase",
+			elements: []string{
+				qmTestNsDC + " title",
+				qmTestNsDC + " description",
+				qmTestNsAlm + " owner",
+				qmTestNsQM + " com.ibm.rqm.planning.editor.section.testCasePreCondition",
+				qmTestNsQM + " com.ibm.rqm.planning.editor.section.testCaseDesign",
+				qmTestNsQM + " estimate",
+				qmTestNsQM + " category",
+				qmTestNsQM + " category",
+				qmTestNsQM + " remotescript",
+				qmTestNsQM + " testscript",
+			},
+		},
+		{
+			name: "testplan",
+			get: func(ctx context.Context, proj *QMProject, id string) (QMObject, error) {
+				return QMGet[*QMTestPlan](ctx, proj, id)
+			},
+			root: qmTestNsQM + " testplan",
+			elements: []string{
+				qmTestNsDC + " title",
+				qmTestNsDC + " description",
+				qmTestNsQM + " configuration",
+				qmTestNsQM + " testcase",
+				qmTestNsQM + " testcase",
+			},
+		},
+		{
+			name: "testscript",
+			get: func(ctx context.Context, proj *QMProject, id string) (QMObject, error) {
+				return QMGet[*QMManualTestScript](ctx, proj, id)
+			},
+			root: qmTestNsQM + " testscript",
+			elements: []string{
+				qmTestNsDC + " title",
+				qmTestNsAlm + " owner",
+				qmTestNsQM + " steps",
+			},
+		},
+		{
+			name: "remotescript",
+			get: func(ctx context.Context, proj *QMProject, id string) (QMObject, error) {
+				return QMGet[*QMAutomaticTestScript](ctx, proj, id)
+			},
+			root: qmTestNsQM + " remotescript",
+			elements: []string{
+				qmTestNsDC + " title",
+				qmTestNsDC + " description",
+				qmTestNsAlm + " owner",
+				qmTestNsQM + " command",
+				qmTestNsQM + " arguments",
+			},
+		},
+		{
+			name: "configuration",
+			get: func(ctx context.Context, proj *QMProject, id string) (QMObject, error) {
+				return QMGet[*QMTestEnvironment](ctx, proj, id)
+			},
+			root: qmTestNsQM + " configuration",
+			elements: []string{
+				qmTestNsDC + " title",
+				qmTestNsQM + " summary",
+			},
+		},
+	}
+
+	proj := qmTestFixtureProject(t)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			obj, err := test.get(context.Background(), proj, "id")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			root := qmTestDump(t, obj)
+			if qmTestName(root) != test.root {
+				t.Errorf("got root %s, expected %s", qmTestName(root), test.root)
+			}
+			if names := qmTestChildNames(root); !reflect.DeepEqual(names, test.elements) {
+				t.Errorf("got elements:\n%v\nexpected:\n%v", names, test.elements)
+			}
+		})
+	}
+}
+
+func TestQMDumpXmlTestCase(t *testing.T) {
+	testCase, err := QMGet[*QMTestCase](context.Background(), qmTestFixtureProject(t), "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := qmTestDump(t, testCase)
+
+	// text is escaped
+	if title := root.SelectElement("title").Text(); title != "Login & logout" {
+		t.Errorf("got title %s", title)
+	}
+	if description := root.SelectElement("description").Text(); description != "Checks the <login> dialog" {
+		t.Errorf("got description %s", description)
+	}
+	if estimate := root.SelectElement("estimate").Text(); estimate != "3600000" {
+		t.Errorf("got estimate %s", estimate)
+	}
+
+	// rich text is kept as XHTML
+	design := root.SelectElement("com.ibm.rqm.planning.editor.section.testCaseDesign")
+	div := design.SelectElement("div")
+	if div == nil || qmTestName(div) != qmTestNsXhtml+" div" {
+		t.Fatalf("expected XHTML div in design, got %v", qmTestChildNames(design))
+	}
+	if p := div.SelectElement("p"); p == nil || qmTestName(p) != qmTestNsXhtml+" p" || p.Text() != "Login with 1 < 2 & 3" {
+		t.Errorf("got paragraph %v", qmTestChildNames(div))
+	}
+
+	// categories and references as attributes
+	expected := [][]string{
+		{" term=Function", " value=Login"},
+		{" term=Component", " value=UI"},
+	}
+	for i, category := range root.SelectElements("category") {
+		if attrs := qmTestAttrNames(category); !reflect.DeepEqual(attrs, expected[i]) {
+			t.Errorf("got attributes %v of category %d", attrs, i)
+		}
+	}
+	remoteScript := root.SelectElement("remotescript")
+	if href := remoteScript.SelectAttrValue("href", ""); href != testCase.AutomaticTestScriptRefs[0].Href {
+		t.Errorf("got remote script %s", href)
+	}
+}
+
+func TestQMDumpXmlTestScript(t *testing.T) {
+	testScript, err := QMGet[*QMManualTestScript](context.Background(), qmTestFixtureProject(t), "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps := qmTestDump(t, testScript).SelectElement("steps")
+
+	tests := []struct {
+		attrs    []string
+		elements []string
+	}{
+		{
+			attrs: []string{
+				qmTestNsTestScript + " type=execution",
+				qmTestNsTestScript + " stepIndex=1",
+			},
+			elements: []string{
+				qmTestNsTestScript + " name",
+				qmTestNsTestScript + " description",
+				qmTestNsTestScript + " expectedResult",
+			},
+		},
+		{
+			attrs: []string{
+				qmTestNsTestScript + " type=verification",
+				qmTestNsTestScript + " stepIndex=2",
+			},
+			elements: []string{
+				qmTestNsTestScript + " name",
+				qmTestNsTestScript + " link",
+			},
+		},
+	}
+
+	elements := steps.ChildElements()
+	if len(elements) != len(tests) {
+		t.Fatalf("got %d steps, expected %d", len(elements), len(tests))
+	}
+	for i, test := range tests {
+		if name := qmTestName(elements[i]); name != qmTestNsTestScript+" step" {
+			t.Errorf("got step %s", name)
+		}
+		if attrs := qmTestAttrNames(elements[i]); !reflect.DeepEqual(attrs, test.attrs) {
+			t.Errorf("got attributes %v of step %d", attrs, i)
+		}
+		if names := qmTestChildNames(elements[i]); !reflect.DeepEqual(names, test.elements) {
+			t.Errorf("got elements %v of step %d", names, i)
+		}
+	}
+
+	if name := elements[1].SelectElement("name").Text(); name != "Check user & role" {
+		t.Errorf("got name %s", name)
+	}
+	description := elements[0].SelectElement("description").SelectElement("div")
+	if description == nil || qmTestName(description) != qmTestNsXhtml+" div" {
+		t.Errorf("expected XHTML description, got %v", qmTestChildNames(elements[0].SelectElement("description")))
+	}
+}
+
+func TestQMDumpXmlRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  QMObject
+		new  func() QMObject
+	}{
+		{
+			name: "testcase",
+			obj: &QMTestCase{
+				Title:        "a < b & c",
+				Description:  `"quoted" & 'single'`,
+				PreCondition: `<div xmlns="http://www.w3.org/1999/xhtml">1 &lt; 2 &amp; <b>bold</b></div>`,
+				Design:       `<div xmlns="http://www.w3.org/1999/xhtml"><p>a</p><p>b</p></div>`,
+				Estimate:     QMDuration(90 * time.Second),
+				Categories:   []QMCategory{{Name: "a & b", Value: "<c>"}},
+				ManualTestScriptRefs: QMRefList{
+					{Href: "https://jazz.example.com/testscript?a=1&b=2"},
+				},
+			},
+			new: func() QMObject { return new(QMTestCase) },
+		},
+		{
+			name: "testscript",
+			obj: &QMManualTestScript{
+				Title: "<script>",
+				Steps: []QMTestStep{{
+					Index:           1,
+					Type:            QMStepTypeExecution,
+					Name:            "step & name",
+					Description:     `<div xmlns="http://www.w3.org/1999/xhtml">&lt;tag&gt;</div>`,
+					RequirementRefs: QMRefList{{Href: "req"}},
+				}},
+			},
+			new: func() QMObject { return new(QMManualTestScript) },
+		},
+		{
+			name: "executionresult",
+			obj: &QMTestExecutionResult{
+				State:       QMResultStatePassed,
+				Machine:     "host & port",
+				StartTime:   time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC),
+				Variables:   QMVariableMap{"a": "1 < 2", "b": "&"},
+				TestCaseRef: QMRef{Href: "testcase"},
+				StepResults: []QMStepResult{{
+					Index:   1,
+					Result:  QMResultStateFailed,
+					Comment: `<div xmlns="http://www.w3.org/1999/xhtml">x &amp; y</div>`,
+				}},
+			},
+			new: func() QMObject { return new(QMTestExecutionResult) },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := test.obj.Spec().DumpXml(test.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			obj := test.new()
+			err = xml.Unmarshal(data, obj)
+			if err != nil {
+				t.Fatalf("invalid XML: %v\n%s", err, data)
+			}
+			if !reflect.DeepEqual(obj, test.obj) {
+				t.Errorf("got:\n%+v\nexpected:\n%+v\ndocument:\n%s", obj, test.obj, data)
+			}
+		})
+	}
+}
+
+func TestQMDumpXmlRichText(t *testing.T) {
+	root := qmTestDump(t, &QMTestCase{Design: `text <b>bold</b> <h:p xmlns:h="http://www.w3.org/1999/xhtml">p</h:p>`})
+	design := root.SelectElement("com.ibm.rqm.planning.editor.section.testCaseDesign")
+
+	// elements without namespace are written as XHTML
+	expected := []string{qmTestNsXhtml + " b", qmTestNsXhtml + " p"}
+	if names := qmTestChildNames(design); !reflect.DeepEqual(names, expected) {
+		t.Errorf("got elements %v, expected %v", names, expected)
+	}
+	if text := design.Text(); text != "text " {
+		t.Errorf("got text %q", text)
+	}
+}
+
+func TestQMDumpXmlPlainText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"ampersand", "a & b", "a &amp; b"},
+		{"less than", "a < b", "a &lt; b"},
+		{"unclosed element", "<p>text", "&lt;p&gt;text"},
+		{"invalid element", "<p>text</b>", "&lt;p&gt;text&lt;/b&gt;"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			obj := &QMTestCase{Design: QMXmlText(test.text)}
+			data, err := obj.Spec().DumpXml(obj)
+			if err != nil {
+				t.Fatalf("failed to dump test case: %v", err)
+			}
+			if !strings.Contains(string(data), ">"+test.expected+"</") {
+				t.Errorf("escaped text %q not found in %s", test.expected, data)
+			}
+
+			// rich text is read as inner XML and keeps the escaped text
+			var loaded QMTestCase
+			err = xml.Unmarshal(data, &loaded)
+			if err != nil {
+				t.Fatalf("failed to load test case: %v", err)
+			}
+			if string(loaded.Design) != test.expected {
+				t.Errorf("got design %q, expected %q", loaded.Design, test.expected)
+			}
+		})
+	}
+}
+
+func TestQMDumpXmlErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  QMObject
+	}{
+		{"not writable", &QMAttachment{Title: "attachment"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.obj.Spec().DumpXml(test.obj)
+			if err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestQMWriteXmlFields(t *testing.T) {
+	objects := []qmWritable{
+		&QMTestEnvironment{},
+		&QMTestCase{},
+		&QMManualTestScript{},
+		&QMAutomaticTestScript{},
+		&QMTestExecutionRecord{},
+		&QMTestExecutionResult{},
+		&QMTestPlan{},
+	}
+
+	for _, obj := range objects {
+		read := reflect.TypeOf(obj).Elem()
+		t.Run(read.Name(), func(t *testing.T) {
+			qmTestCompareXml(t, read, reflect.TypeOf(obj.writeXml()).Elem())
+		})
+	}
+}
+
+// qmTestCompareXml checks that every field of the written document exists in
+// the read struct with the same element path
+func qmTestCompareXml(t *testing.T, read, write reflect.Type) {
+	t.Helper()
+	for i := 0; i < write.NumField(); i++ {
+		field := write.Field(i)
+		if field.Name == "XMLName" {
+			continue
+		}
+		readField, ok := read.FieldByName(field.Name)
+		if !ok {
+			t.Errorf("%s.%s has no field in %s", write.Name(), field.Name, read.Name())
+			continue
+		}
+
+		path, attr := qmTestXmlPath(field.Tag)
+		writeType := qmTestElemType(field.Type)
+		// written wrapper of a list (e.g. steps>step)
+		if writeType.Kind() == reflect.Struct && writeType.NumField() == 1 &&
+			strings.HasSuffix(writeType.Name(), "Xml") {
+			inner, _ := qmTestXmlPath(writeType.Field(0).Tag)
+			path = append(path, inner...)
+			writeType = qmTestElemType(writeType.Field(0).Type)
+		}
+
+		readPath, readAttr := qmTestXmlPath(readField.Tag)
+		if !reflect.DeepEqual(path, readPath) || attr != readAttr {
+			t.Errorf("%s.%s is written as %v (attr %t), read as %v (attr %t)",
+				write.Name(), field.Name, path, attr, readPath, readAttr)
+		}
+
+		readType := qmTestElemType(readField.Type)
+		if writeType != readType && writeType.Kind() == reflect.Struct {
+			qmTestCompareXml(t, readType, writeType)
+		}
+	}
+}
+
+// qmTestXmlPath returns the element path without namespace of a struct tag
+func qmTestXmlPath(tag reflect.StructTag) ([]string, bool) {
+	name, options, _ := strings.Cut(tag.Get("xml"), ",")
+	if i := strings.LastIndex(name, " "); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.Split(name, ">"), strings.Contains(options, "attr")
+}
+
+// qmTestElemType returns the element type of pointers and slices
+func qmTestElemType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	return typ
+}
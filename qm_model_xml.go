@@ -0,0 +1,313 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// qmWritable is implemented by QM objects that can be created or updated.
+//
+// The xml tags of the QM objects have no namespace, so documents of the
+// server are read regardless of the prefixes used. Written documents require
+// the namespaces and contain only a part of the fields, so each writable
+// object returns a dedicated document with namespaced xml tags. The fields of
+// these documents have the same names as the fields of the object (used by
+// QMUpdate to find the changed elements). Fields assigned by the server are
+// not written, except the WebId of execution results. TestQMWriteXmlFields
+// checks that the documents match the xml tags of the objects.
+type qmWritable interface {
+	// writeXml returns the document with the writable fields
+	writeXml() any
+}
+
+// qmXmlName of the element written for the field with the given name of
+// the document (false if the field is not written)
+func qmXmlName(document reflect.Type, fieldName string) (xml.Name, bool) {
+	field, ok := document.FieldByName(fieldName)
+	if !ok || field.Name == "XMLName" {
+		return xml.Name{}, false
+	}
+
+	name := strings.Split(field.Tag.Get("xml"), ",")[0]
+	if i := strings.LastIndex(name, " "); i >= 0 {
+		return xml.Name{Space: name[:i], Local: name[i+1:]}, true
+	}
+	return xml.Name{Local: name}, true
+}
+
+// qmTimeXml formats the time for written documents (empty for zero time)
+func qmTimeXml(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// qmRefXml returns the reference or nil if it is empty
+func qmRefXml(ref QMRef) *QMRef {
+	if ref.Href == "" {
+		return nil
+	}
+	return &ref
+}
+
+// qmRefListXml returns the list without empty references
+func qmRefListXml(refs QMRefList) QMRefList {
+	var list QMRefList
+	for _, ref := range refs {
+		if ref.Href != "" {
+			list = append(list, ref)
+		}
+	}
+	return list
+}
+
+// qmTestEnvironmentXml is the written document of QMTestEnvironment
+type qmTestEnvironmentXml struct {
+	XMLName xml.Name `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ configuration"`
+	Title   string   `xml:"http://purl.org/dc/elements/1.1/ title,omitempty"`
+	Summary string   `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ summary,omitempty"`
+}
+
+func (o *QMTestEnvironment) writeXml() any {
+	return &qmTestEnvironmentXml{
+		Title:   o.Title,
+		Summary: o.Summary,
+	}
+}
+
+// qmTestCaseXml is the written document of QMTestCase
+type qmTestCaseXml struct {
+	XMLName                 xml.Name     `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ testcase"`
+	Title                   string       `xml:"http://purl.org/dc/elements/1.1/ title,omitempty"`
+	Description             string       `xml:"http://purl.org/dc/elements/1.1/ description,omitempty"`
+	Owner                   string       `xml:"http://jazz.net/xmlns/alm/v0.1/ owner,omitempty"`
+	PreCondition            QMXmlText    `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ com.ibm.rqm.planning.editor.section.testCasePreCondition,omitempty"`
+	Design                  QMXmlText    `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ com.ibm.rqm.planning.editor.section.testCaseDesign,omitempty"`
+	PostCondition           QMXmlText    `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ com.ibm.rqm.planning.editor.section.testCasePostCondition,omitempty"`
+	Estimate                QMDuration   `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ estimate,omitempty"`
+	Categories              []QMCategory `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ category"`
+	AutomaticTestScriptRefs QMRefList    `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ remotescript"`
+	ManualTestScriptRefs    QMRefList    `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ testscript"`
+}
+
+func (o *QMTestCase) writeXml() any {
+	return &qmTestCaseXml{
+		Title:                   o.Title,
+		Description:             o.Description,
+		Owner:                   o.Owner,
+		PreCondition:            o.PreCondition,
+		Design:                  o.Design,
+		PostCondition:           o.PostCondition,
+		Estimate:                o.Estimate,
+		Categories:              o.Categories,
+		AutomaticTestScriptRefs: qmRefListXml(o.AutomaticTestScriptRefs),
+		ManualTestScriptRefs:    qmRefListXml(o.ManualTestScriptRefs),
+	}
+}
+
+// qmManualTestScriptXml is the written document of QMManualTestScript
+type qmManualTestScriptXml struct {
+	XMLName     xml.Name        `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ testscript"`
+	Title       string          `xml:"http://purl.org/dc/elements/1.1/ title,omitempty"`
+	Description string          `xml:"http://purl.org/dc/elements/1.1/ description,omitempty"`
+	Owner       string          `xml:"http://jazz.net/xmlns/alm/v0.1/ owner,omitempty"`
+	Steps       *qmTestStepsXml `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ steps"`
+}
+
+// qmTestStepsXml is the written list of QMTestStep
+type qmTestStepsXml struct {
+	Steps []qmTestStepXml `xml:"http://jazz.net/xmlns/alm/qm/v0.1/testscript/v0.1/ step"`
+}
+
+// qmTestStepXml is the written QMTestStep
+type qmTestStepXml struct {
+	Type            string    `xml:"http://jazz.net/xmlns/alm/qm/v0.1/testscript/v0.1/ type,attr"`
+	Index           int       `xml:"http://jazz.net/xmlns/alm/qm/v0.1/testscript/v0.1/ stepIndex,attr"`
+	Name            string    `xml:"http://jazz.net/xmlns/alm/qm/v0.1/testscript/v0.1/ name,omitempty"`
+	Description     QMXmlText `xml:"http://jazz.net/xmlns/alm/qm/v0.1/testscript/v0.1/ description,omitempty"`
+	ExpectedResult  QMXmlText `xml:"http://jazz.net/xmlns/alm/qm/v0.1/testscript/v0.1/ expectedResult,omitempty"`
+	AttachmentRefs  QMRefList `xml:"http://jazz.net/xmlns/alm/qm/v0.1/testscript/v0.1/ attachment"`
+	RequirementRefs QMRefList `xml:"http://jazz.net/xmlns/alm/qm/v0.1/testscript/v0.1/ link"`
+}
+
+func (o *QMManualTestScript) writeXml() any {
+	document := &qmManualTestScriptXml{
+		Title:       o.Title,
+		Description: o.Description,
+		Owner:       o.Owner,
+	}
+
+	if len(o.Steps) > 0 {
+		document.Steps = &qmTestStepsXml{
+			Steps: make([]qmTestStepXml, len(o.Steps)),
+		}
+		for i, step := range o.Steps {
+			// index and type are required by the server
+			if step.Index == 0 {
+				step.Index = i + 1
+			}
+			if step.Type == "" {
+				step.Type = QMStepTypeExecution
+			}
+
+			document.Steps.Steps[i] = qmTestStepXml{
+				Type:            step.Type,
+				Index:           step.Index,
+				Name:            step.Name,
+				Description:     step.Description,
+				ExpectedResult:  step.ExpectedResult,
+				AttachmentRefs:  qmRefListXml(step.AttachmentRefs),
+				RequirementRefs: qmRefListXml(step.RequirementRefs),
+			}
+		}
+	}
+	return document
+}
+
+// qmAutomaticTestScriptXml is the written document of QMAutomaticTestScript
+type qmAutomaticTestScriptXml struct {
+	XMLName     xml.Name `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ remotescript"`
+	Title       string   `xml:"http://purl.org/dc/elements/1.1/ title,omitempty"`
+	Description string   `xml:"http://purl.org/dc/elements/1.1/ description,omitempty"`
+	Owner       string   `xml:"http://jazz.net/xmlns/alm/v0.1/ owner,omitempty"`
+	Command     string   `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ command,omitempty"`
+	Arguments   string   `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ arguments,omitempty"`
+}
+
+func (o *QMAutomaticTestScript) writeXml() any {
+	return &qmAutomaticTestScriptXml{
+		Title:       o.Title,
+		Description: o.Description,
+		Owner:       o.Owner,
+		Command:     o.Command,
+		Arguments:   o.Arguments,
+	}
+}
+
+// qmTestExecutionRecordXml is the written document of QMTestExecutionRecord
+type qmTestExecutionRecordXml struct {
+	XMLName            xml.Name `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ executionworkitem"`
+	Title              string   `xml:"http://purl.org/dc/elements/1.1/ title,omitempty"`
+	TestPlanRef        *QMRef   `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ testplan"`
+	TestCaseRef        *QMRef   `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ testcase"`
+	TestEnvironmentRef *QMRef   `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ configuration"`
+}
+
+func (o *QMTestExecutionRecord) writeXml() any {
+	return &qmTestExecutionRecordXml{
+		Title:              o.Title,
+		TestPlanRef:        qmRefXml(o.TestPlanRef),
+		TestCaseRef:        qmRefXml(o.TestCaseRef),
+		TestEnvironmentRef: qmRefXml(o.TestEnvironmentRef),
+	}
+}
+
+// qmTestExecutionResultXml is the written document of QMTestExecutionResult
+type qmTestExecutionResultXml struct {
+	XMLName                xml.Name          `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ executionresult"`
+	WebId                  int               `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ webId,omitempty"`
+	State                  string            `xml:"http://jazz.net/xmlns/alm/v0.1/ state,omitempty"`
+	Machine                string            `xml:"http://jazz.net/xmlns/alm/qm/v0.1/executionresult/v0.1 machine,omitempty"`
+	StartTime              string            `xml:"http://jazz.net/xmlns/alm/qm/v0.1/executionresult/v0.1 starttime,omitempty"`
+	EndTime                string            `xml:"http://jazz.net/xmlns/alm/qm/v0.1/executionresult/v0.1 endtime,omitempty"`
+	Variables              QMVariableMap     `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ variables,omitempty"`
+	TestPlanRef            *QMRef            `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ testplan"`
+	TestCaseRef            *QMRef            `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ testcase"`
+	TestEnvironmentRef     *QMRef            `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ configuration"`
+	TestExecutionRecordRef *QMRef            `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ executionworkitem"`
+	AutomaticTestScriptRef *QMRef            `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ remotescript"`
+	ManualTestScriptRef    *QMRef            `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ testscript"`
+	AttachmentRefs         QMRefList         `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ attachment"`
+	StepResults            *qmStepResultsXml `xml:"http://jazz.net/xmlns/alm/qm/v0.1/executionresult/v0.1 stepResults"`
+}
+
+// qmStepResultsXml is the written list of QMStepResult
+type qmStepResultsXml struct {
+	StepResults []qmStepResultXml `xml:"http://jazz.net/xmlns/alm/qm/v0.1/executionresult/v0.1 stepResult"`
+}
+
+// qmStepResultXml is the written QMStepResult
+type qmStepResultXml struct {
+	Index          int       `xml:"http://jazz.net/xmlns/alm/qm/v0.1/executionresult/v0.1 stepIndex,attr"`
+	Result         string    `xml:"http://jazz.net/xmlns/alm/qm/v0.1/executionresult/v0.1 result,attr,omitempty"`
+	Description    QMXmlText `xml:"http://jazz.net/xmlns/alm/qm/v0.1/executionresult/v0.1 description,omitempty"`
+	ExpectedResult QMXmlText `xml:"http://jazz.net/xmlns/alm/qm/v0.1/executionresult/v0.1 expectedResult,omitempty"`
+	ActualResult   QMXmlText `xml:"http://jazz.net/xmlns/alm/qm/v0.1/executionresult/v0.1 actualResult,omitempty"`
+	Comment        QMXmlText `xml:"http://jazz.net/xmlns/alm/qm/v0.1/executionresult/v0.1 comment,omitempty"`
+}
+
+func (o *QMTestExecutionResult) writeXml() any {
+	document := &qmTestExecutionResultXml{
+		WebId:                  o.WebId,
+		State:                  o.State,
+		Machine:                o.Machine,
+		StartTime:              qmTimeXml(o.StartTime),
+		EndTime:                qmTimeXml(o.EndTime),
+		Variables:              o.Variables,
+		TestPlanRef:            qmRefXml(o.TestPlanRef),
+		TestCaseRef:            qmRefXml(o.TestCaseRef),
+		TestEnvironmentRef:     qmRefXml(o.TestEnvironmentRef),
+		TestExecutionRecordRef: qmRefXml(o.TestExecutionRecordRef),
+		AutomaticTestScriptRef: qmRefXml(o.AutomaticTestScriptRef),
+		ManualTestScriptRef:    qmRefXml(o.ManualTestScriptRef),
+		AttachmentRefs:         qmRefListXml(o.AttachmentRefs),
+	}
+
+	if len(o.StepResults) > 0 {
+		document.StepResults = &qmStepResultsXml{
+			StepResults: make([]qmStepResultXml, len(o.StepResults)),
+		}
+		for i, result := range o.StepResults {
+			if result.Index == 0 {
+				result.Index = i + 1
+			}
+
+			document.StepResults.StepResults[i] = qmStepResultXml{
+				Index:          result.Index,
+				Result:         result.Result,
+				Description:    result.Description,
+				ExpectedResult: result.ExpectedResult,
+				ActualResult:   result.ActualResult,
+				Comment:        result.Comment,
+			}
+		}
+	}
+	return document
+}
+
+// qmTestPlanXml is the written document of QMTestPlan
+type qmTestPlanXml struct {
+	XMLName             xml.Name  `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ testplan"`
+	Title               string    `xml:"http://purl.org/dc/elements/1.1/ title,omitempty"`
+	Description         string    `xml:"http://purl.org/dc/elements/1.1/ description,omitempty"`
+	TestEnvironmentRefs QMRefList `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ configuration"`
+	TestCaseRefs        QMRefList `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ testcase"`
+	TestSuiteRefs       QMRefList `xml:"http://jazz.net/xmlns/alm/qm/v0.1/ testsuite"`
+}
+
+func (o *QMTestPlan) writeXml() any {
+	return &qmTestPlanXml{
+		Title:               o.Title,
+		Description:         o.Description,
+		TestEnvironmentRefs: qmRefListXml(o.TestEnvironmentRefs),
+		TestCaseRefs:        qmRefListXml(o.TestCaseRefs),
+		TestSuiteRefs:       qmRefListXml(o.TestSuiteRefs),
+	}
+}
@@ -0,0 +1,367 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	_ "embed"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"time"
+)
+
+//go:embed qm_plan_report.html
+var qmPlanReportTemplate string
+
+// qmResultStateNames contains readable names of QMResultState* constants
+var qmResultStateNames = map[string]string{
+	QMResultStatePaused:       "Paused",
+	QMResultStateInProgress:   "In Progress",
+	QMResultStateNotRun:       "Not Run",
+	QMResultStatePassed:       "Passed",
+	QMResultStatePermFailed:   "Permanently Failed",
+	QMResultStateIncomplete:   "Incomplete",
+	QMResultStateInconclusive: "Inconclusive",
+	QMResultStatePartBlocked:  "Partially Blocked",
+	QMResultStateDeferred:     "Deferred",
+	QMResultStateFailed:       "Failed",
+	QMResultStateError:        "Error",
+	QMResultStateBlocked:      "Blocked",
+}
+
+// QMResultStateName returns a readable name of the given result state
+func QMResultStateName(state string) string {
+	if name, ok := qmResultStateNames[state]; ok {
+		return name
+	}
+	if state == "" {
+		return "Not Run"
+	}
+	return state
+}
+
+// QMPlanReport contains the latest results of a test plan
+type QMPlanReport struct {
+	// TestPlan of report
+	TestPlan *QMTestPlan
+
+	// Created time of report
+	Created time.Time
+
+	// Environments with the results sorted by title
+	Environments []*QMPlanReportEnvironment
+}
+
+// QMPlanReportEnvironment contains the latest results of a test environment
+type QMPlanReportEnvironment struct {
+	// TestEnvironment of results (nil for records without environment)
+	TestEnvironment *QMTestEnvironment
+
+	// Entries sorted by test case title
+	Entries []*QMPlanReportEntry
+}
+
+// QMPlanReportEntry is a single execution record of a QMPlanReport
+type QMPlanReportEntry struct {
+	// TestCase of execution record
+	TestCase *QMTestCase
+
+	// TestExecutionRecord of entry
+	TestExecutionRecord *QMTestExecutionRecord
+
+	// LastResult of the execution record (nil if never executed)
+	LastResult *QMTestExecutionResult
+}
+
+// State of the last result (empty if never executed)
+func (e *QMPlanReportEntry) State() string {
+	if e.LastResult == nil {
+		return ""
+	}
+	return e.LastResult.State
+}
+
+// Title of the test environment
+func (e *QMPlanReportEnvironment) Title() string {
+	if e.TestEnvironment == nil {
+		return "No Environment"
+	}
+	return e.TestEnvironment.Title
+}
+
+// Count returns the number of entries with the given states
+// (entries without result are counted as QMResultStateNotRun)
+func (e *QMPlanReportEnvironment) Count(states ...string) int {
+	var count int
+	for _, entry := range e.Entries {
+		state := entry.State()
+		if state == "" {
+			state = QMResultStateNotRun
+		}
+		for _, s := range states {
+			if s == state {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// Passed returns the number of passed entries
+func (e *QMPlanReportEnvironment) Passed() int {
+	return e.Count(QMResultStatePassed)
+}
+
+// Failed returns the number of failed entries (including errors)
+func (e *QMPlanReportEnvironment) Failed() int {
+	return e.Count(QMResultStateFailed, QMResultStatePermFailed, QMResultStateError)
+}
+
+// Blocked returns the number of blocked entries
+func (e *QMPlanReportEnvironment) Blocked() int {
+	return e.Count(QMResultStateBlocked, QMResultStatePartBlocked)
+}
+
+// NotRun returns the number of entries that were not executed
+func (e *QMPlanReportEnvironment) NotRun() int {
+	return e.Count(QMResultStateNotRun)
+}
+
+// NewQMPlanReport loads the latest results of all execution records of the
+// given test plan
+func NewQMPlanReport(ctx context.Context, testPlan *QMTestPlan) (*QMPlanReport, error) {
+	records, err := testPlan.TestExecutionRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load execution records: %w", err)
+	}
+
+	// collect referenced objects
+	var testCaseIds, environmentIds, resultIds []string
+	known := make(map[string]bool)
+	add := func(ids *[]string, href string) {
+		if href != "" && !known[href] {
+			known[href] = true
+			*ids = append(*ids, href)
+		}
+	}
+	for _, record := range records {
+		add(&testCaseIds, record.TestCaseRef.Href)
+		add(&environmentIds, record.TestEnvironmentRef.Href)
+		add(&resultIds, record.LastExecutionResultRef.Href)
+	}
+
+	testCases, err := qmGetOrderedList[*QMTestCase](ctx, testPlan.proj, testCaseIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load test cases: %w", err)
+	}
+	environments, err := qmGetOrderedList[*QMTestEnvironment](ctx, testPlan.proj, environmentIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load test environments: %w", err)
+	}
+	results, err := qmGetOrderedList[*QMTestExecutionResult](ctx, testPlan.proj, resultIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load execution results: %w", err)
+	}
+
+	testCaseMap := make(map[string]*QMTestCase, len(testCases))
+	for i, testCase := range testCases {
+		testCaseMap[testCaseIds[i]] = testCase
+	}
+	resultMap := make(map[string]*QMTestExecutionResult, len(results))
+	for i, result := range results {
+		resultMap[resultIds[i]] = result
+	}
+	environmentMap := make(map[string]*QMPlanReportEnvironment, len(environments)+1)
+	for i, environment := range environments {
+		environmentMap[environmentIds[i]] = &QMPlanReportEnvironment{
+			TestEnvironment: environment,
+		}
+	}
+
+	// group records by environment
+	report := &QMPlanReport{
+		TestPlan: testPlan,
+		Created:  time.Now(),
+	}
+	for _, record := range records {
+		environment, ok := environmentMap[record.TestEnvironmentRef.Href]
+		if !ok {
+			environment = new(QMPlanReportEnvironment)
+			environmentMap[record.TestEnvironmentRef.Href] = environment
+		}
+		environment.Entries = append(environment.Entries, &QMPlanReportEntry{
+			TestCase:            testCaseMap[record.TestCaseRef.Href],
+			TestExecutionRecord: record,
+			LastResult:          resultMap[record.LastExecutionResultRef.Href],
+		})
+	}
+	for _, environment := range environmentMap {
+		if len(environment.Entries) == 0 {
+			continue
+		}
+		sort.Slice(environment.Entries, func(i, j int) bool {
+			return environment.Entries[i].title() < environment.Entries[j].title()
+		})
+		report.Environments = append(report.Environments, environment)
+	}
+	sort.Slice(report.Environments, func(i, j int) bool {
+		return report.Environments[i].Title() < report.Environments[j].Title()
+	})
+	return report, nil
+}
+
+// title of the entry (test case title or title of the execution record)
+func (e *QMPlanReportEntry) title() string {
+	if e.TestCase != nil {
+		return e.TestCase.Title
+	}
+	return e.TestExecutionRecord.Title
+}
+
+// WriteHTML writes the report as self-contained HTML page
+func (r *QMPlanReport) WriteHTML(w io.Writer) error {
+	tpl, err := template.New("report").Funcs(template.FuncMap{
+		"stateName": QMResultStateName,
+		"stateClass": func(state string) string {
+			switch state {
+			case QMResultStatePassed:
+				return "passed"
+			case QMResultStateFailed, QMResultStatePermFailed, QMResultStateError:
+				return "failed"
+			case QMResultStateBlocked, QMResultStatePartBlocked:
+				return "blocked"
+			}
+			return "other"
+		},
+		"formatTime": func(t time.Time) string {
+			if t.IsZero() {
+				return ""
+			}
+			return t.Format("2006-01-02 15:04:05")
+		},
+	}).Parse(qmPlanReportTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse report template: %w", err)
+	}
+
+	err = tpl.Execute(w, r)
+	if err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
+	return nil
+}
+
+// junitReportSuites is the root of a JUnit XML report
+type junitReportSuites struct {
+	XMLName  xml.Name           `xml:"testsuites"`
+	Name     string             `xml:"name,attr"`
+	Tests    int                `xml:"tests,attr"`
+	Failures int                `xml:"failures,attr"`
+	Errors   int                `xml:"errors,attr"`
+	Skipped  int                `xml:"skipped,attr"`
+	Suites   []junitReportSuite `xml:"testsuite"`
+}
+
+// junitReportSuite is a test suite of a JUnit XML report
+type junitReportSuite struct {
+	Name     string            `xml:"name,attr"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Errors   int               `xml:"errors,attr"`
+	Skipped  int               `xml:"skipped,attr"`
+	Cases    []junitReportCase `xml:"testcase"`
+}
+
+// junitReportCase is a test case of a JUnit XML report
+type junitReportCase struct {
+	ClassName string              `xml:"classname,attr"`
+	Name      string              `xml:"name,attr"`
+	Time      string              `xml:"time,attr,omitempty"`
+	Failure   *junitReportMessage `xml:"failure"`
+	Error     *junitReportMessage `xml:"error"`
+	Skipped   *junitReportMessage `xml:"skipped"`
+}
+
+// junitReportMessage of a failed, errored or skipped test case
+type junitReportMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit writes the report as JUnit XML. Every test environment is
+// written as test suite. Results that are neither passed, failed nor error
+// are reported as skipped.
+func (r *QMPlanReport) WriteJUnit(w io.Writer) error {
+	suites := junitReportSuites{
+		Name:   r.TestPlan.Title,
+		Suites: make([]junitReportSuite, len(r.Environments)),
+	}
+	for i, environment := range r.Environments {
+		suite := junitReportSuite{
+			Name:  environment.Title(),
+			Tests: len(environment.Entries),
+			Cases: make([]junitReportCase, len(environment.Entries)),
+		}
+		for j, entry := range environment.Entries {
+			testCase := junitReportCase{
+				ClassName: r.TestPlan.Title + "." + environment.Title(),
+				Name:      entry.title(),
+			}
+			if entry.LastResult != nil && !entry.LastResult.StartTime.IsZero() {
+				testCase.Time = fmt.Sprintf("%.3f",
+					entry.LastResult.EndTime.Sub(entry.LastResult.StartTime).Seconds())
+			}
+
+			message := &junitReportMessage{
+				Message: QMResultStateName(entry.State()),
+			}
+			switch entry.State() {
+			case QMResultStatePassed:
+			case QMResultStateFailed, QMResultStatePermFailed:
+				testCase.Failure = message
+				suite.Failures++
+			case QMResultStateError:
+				testCase.Error = message
+				suite.Errors++
+			default:
+				testCase.Skipped = message
+				suite.Skipped++
+			}
+			suite.Cases[j] = testCase
+		}
+
+		suites.Suites[i] = suite
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Skipped += suite.Skipped
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	err = encoder.Encode(suites)
+	if err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,184 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// qmTestPlanReport creates a report with two environments
+func qmTestPlanReport() *QMPlanReport {
+	start := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	result := func(state string) *QMTestExecutionResult {
+		return &QMTestExecutionResult{
+			State:     state,
+			StartTime: start,
+			EndTime:   start.Add(1500 * time.Millisecond),
+			Machine:   "host",
+		}
+	}
+
+	return &QMPlanReport{
+		TestPlan: &QMTestPlan{Title: "Plan <1>"},
+		Created:  start,
+		Environments: []*QMPlanReportEnvironment{
+			{
+				TestEnvironment: &QMTestEnvironment{Title: "Linux"},
+				Entries: []*QMPlanReportEntry{
+					{
+						TestCase:   &QMTestCase{Title: "passed", WebId: 11},
+						LastResult: result(QMResultStatePassed),
+					},
+					{
+						TestCase:   &QMTestCase{Title: "failed & broken", WebId: 12},
+						LastResult: result(QMResultStateFailed),
+					},
+					{
+						TestCase:   &QMTestCase{Title: "error", WebId: 13},
+						LastResult: result(QMResultStateError),
+					},
+				},
+			},
+			{
+				Entries: []*QMPlanReportEntry{
+					{
+						TestExecutionRecord: &QMTestExecutionRecord{Title: "record"},
+					},
+					{
+						TestCase:   &QMTestCase{Title: "blocked", WebId: 14},
+						LastResult: result(QMResultStateBlocked),
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestQMPlanReportEnvironmentCount(t *testing.T) {
+	report := qmTestPlanReport()
+
+	linux := report.Environments[0]
+	if linux.Passed() != 1 || linux.Failed() != 2 || linux.Blocked() != 0 || linux.NotRun() != 0 {
+		t.Errorf("unexpected counts of %s: %d %d %d %d", linux.Title(),
+			linux.Passed(), linux.Failed(), linux.Blocked(), linux.NotRun())
+	}
+
+	other := report.Environments[1]
+	if other.Title() != "No Environment" {
+		t.Errorf("got title %s", other.Title())
+	}
+	if other.Passed() != 0 || other.Failed() != 0 || other.Blocked() != 1 || other.NotRun() != 1 {
+		t.Errorf("unexpected counts of %s: %d %d %d %d", other.Title(),
+			other.Passed(), other.Failed(), other.Blocked(), other.NotRun())
+	}
+}
+
+func TestQMPlanReportWriteJUnit(t *testing.T) {
+	var buffer bytes.Buffer
+	err := qmTestPlanReport().WriteJUnit(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="Plan &lt;1&gt;" tests="5" failures="1" errors="1" skipped="2">
+  <testsuite name="Linux" tests="3" failures="1" errors="1" skipped="0">
+    <testcase classname="Plan &lt;1&gt;.Linux" name="passed" time="1.500"></testcase>
+    <testcase classname="Plan &lt;1&gt;.Linux" name="failed &amp; broken" time="1.500">
+      <failure message="Failed"></failure>
+    </testcase>
+    <testcase classname="Plan &lt;1&gt;.Linux" name="error" time="1.500">
+      <error message="Error"></error>
+    </testcase>
+  </testsuite>
+  <testsuite name="No Environment" tests="2" failures="0" errors="0" skipped="2">
+    <testcase classname="Plan &lt;1&gt;.No Environment" name="record">
+      <skipped message="Not Run"></skipped>
+    </testcase>
+    <testcase classname="Plan &lt;1&gt;.No Environment" name="blocked" time="1.500">
+      <skipped message="Blocked"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	if buffer.String() != expected {
+		t.Errorf("got JUnit report:\n%s\nexpected:\n%s", buffer.String(), expected)
+	}
+
+	// report can be read by the JUnit parser
+	parsed, err := ParseJUnitReport(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var states []string
+	for _, reportCase := range parsed.Cases {
+		states = append(states, QMResultStateName(reportCase.State))
+	}
+	if strings.Join(states, ",") != "Passed,Failed,Error,Deferred,Deferred" {
+		t.Errorf("got states %s", strings.Join(states, ","))
+	}
+}
+
+func TestQMPlanReportWriteHTML(t *testing.T) {
+	var buffer bytes.Buffer
+	err := qmTestPlanReport().WriteHTML(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := buffer.String()
+
+	for _, expected := range []string{
+		"<title>Plan &lt;1&gt;</title>",
+		"<p class=\"created\">Created 2022-03-01 12:00:00</p>",
+		"<h2>Linux</h2>",
+		"<span class=\"failed\">Failed: 2</span>",
+		"<h2>No Environment</h2>",
+		"<span class=\"other\">Not Run: 1</span>",
+		"<td>failed &amp; broken</td>",
+		"<td class=\"failed\">Failed</td>",
+		"<td class=\"blocked\">Blocked</td>",
+		"<td>record</td>",
+		"<td class=\"other\">Not Run</td>",
+		"<td>2022-03-01 12:00:01</td>",
+		"<td>host</td>",
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("HTML report does not contain %s", expected)
+		}
+	}
+	if strings.Contains(html, "<1>") {
+		t.Error("title is not escaped")
+	}
+}
+
+func TestQMResultStateName(t *testing.T) {
+	tests := []struct {
+		state string
+		name  string
+	}{
+		{QMResultStatePartBlocked, "Partially Blocked"},
+		{"", "Not Run"},
+		{"custom.state", "custom.state"},
+	}
+
+	for _, test := range tests {
+		if name := QMResultStateName(test.state); name != test.name {
+			t.Errorf("got name %s for %s, expected %s", name, test.state, test.name)
+		}
+	}
+}
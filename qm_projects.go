@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/http"
 
 	"golang.org/x/sync/errgroup"
 )
@@ -61,11 +62,11 @@ func QMList[T QMObject](ctx context.Context, proj *QMProject, filter QMFilter) (
 func QMListChan[T QMObject](ctx context.Context, proj *QMProject, filter QMFilter, results chan T) error {
 	// load object returned by list
 	requestChan := make(chan FeedEntry, 100)
-	g := new(errgroup.Group)
+	g, gctx := errgroup.WithContext(ctx)
 	for i := 0; i < proj.qm.client.Worker; i++ {
 		g.Go(func() error {
 			for entry := range requestChan {
-				obj, err := QMGet[T](ctx, proj, entry.Id)
+				obj, err := QMGet[T](gctx, proj, entry.Id)
 				if err != nil {
 					return err
 				}
@@ -75,15 +76,14 @@ func QMListChan[T QMObject](ctx context.Context, proj *QMProject, filter QMFilte
 		})
 	}
 
-	// request object list
-	err := QMListEntryChan[T](ctx, proj, filter, requestChan, false)
-	if err != nil {
-		return err
-	}
+	// request object list (canceled if a worker failed)
+	err := QMListEntryChan[T](gctx, proj, filter, requestChan, false)
 
 	// stop background worker and wait for work is done
 	close(requestChan)
-	err = g.Wait()
+	if workerErr := g.Wait(); workerErr != nil {
+		return workerErr
+	}
 	return err
 }
 
@@ -114,29 +114,75 @@ func qmGetList[T QMObject](ctx context.Context, proj *QMProject, ids []string) (
 
 // qmGetListChan object of the given type returned via a channel
 func qmGetListChan[T QMObject](ctx context.Context, proj *QMProject, ids []string, results chan T) error {
-	// load object returned by list
-	idChan := make(chan string, proj.qm.client.Worker*2)
-	g := new(errgroup.Group)
-	for i := 0; i < proj.qm.client.Worker; i++ {
+	return qmParallel(ctx, proj, len(ids), func(ctx context.Context, index int) error {
+		obj, err := QMGet[T](ctx, proj, ids[index])
+		if err != nil {
+			return err
+		}
+		results <- obj
+		return nil
+	})
+}
+
+// qmGetOrderedList object of the given type in the order of the given IDs
+func qmGetOrderedList[T QMObject](ctx context.Context, proj *QMProject, ids []string) ([]T, error) {
+	results := make([]T, len(ids))
+
+	// load objects by index to keep order
+	err := qmParallel(ctx, proj, len(ids), func(ctx context.Context, index int) error {
+		obj, err := QMGet[T](ctx, proj, ids[index])
+		if err != nil {
+			return err
+		}
+		results[index] = obj
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// qmParallel calls f for all indexes up to count with the worker of the
+// client. Stops on the first error.
+func qmParallel(ctx context.Context, proj *QMProject, count int, f func(ctx context.Context, index int) error) error {
+	indexChan := make(chan int, proj.qm.client.Worker*2)
+	g, gctx := errgroup.WithContext(ctx)
+	worker := 1
+	if proj.qm.client.Worker > 1 {
+		worker = proj.qm.client.Worker
+	}
+	for i := 0; i < worker; i++ {
 		g.Go(func() error {
-			for id := range idChan {
-				obj, err := QMGet[T](ctx, proj, id)
+			for index := range indexChan {
+				// skip remaining indexes after an error
+				if err := gctx.Err(); err != nil {
+					return err
+				}
+
+				err := f(gctx, index)
 				if err != nil {
 					return err
 				}
-				results <- obj
 			}
 			return nil
 		})
 	}
 
-	// add ids to channel
-	for _, id := range ids {
-		idChan <- id
-	}
+	// add indexes to channel (stop if a worker failed)
+	g.Go(func() error {
+		defer close(indexChan)
+		for i := 0; i < count; i++ {
+			select {
+			case indexChan <- i:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+		}
+		return nil
+	})
 
-	// stop background worker and wait for work is done
-	close(idChan)
+	// wait for work is done
 	return g.Wait()
 }
 
@@ -156,12 +202,20 @@ func QMGet[T QMObject](ctx context.Context, proj *QMProject, id string) (T, erro
 		return value, errorFromResponse(fmt.Sprintf("failed to get %s", spec.ResourceID), response, nil)
 	}
 
-	err = xml.NewDecoder(response.Body).Decode(&value)
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return value, fmt.Errorf("failed to get %s: %w", spec.ResourceID, err)
+	}
+	err = xml.Unmarshal(data, &value)
 	if err != nil {
 		return value, fmt.Errorf("failed to parse %s: %w", spec.ResourceID, err)
 	}
 
 	value.SetProj(proj)
+	value.SetETag(response.Header.Get("ETag"))
+	if raw, ok := any(value).(qmRawObject); ok {
+		raw.setRawXml(data)
+	}
 	return value, nil
 }
 
@@ -183,15 +237,21 @@ func QMSave[T QMObject](ctx context.Context, proj *QMProject, obj T) (T, error)
 	}
 
 	// encode object
-	data := obj.Spec().DumpXml(obj)
+	data, err := obj.Spec().DumpXml(obj)
+	if err != nil {
+		return obj, fmt.Errorf("failed to save object: %w", err)
+	}
 
-	// send request to server
-	response, err := proj.qm.client.put(ctx, obj.Ref().Href, "application/xml", bytes.NewBuffer(data))
+	// send request to server (only if the object was not modified since loaded)
+	response, err := proj.qm.client.putIfMatch(ctx, obj.Ref().Href, "application/xml", obj.ETag(), bytes.NewBuffer(data))
 	if err != nil {
 		return obj, fmt.Errorf("failed to save object: %w", err)
 	}
 	defer response.Body.Close()
 
+	if response.StatusCode == http.StatusPreconditionFailed {
+		return obj, qmConflictError[T](ctx, proj, obj, response)
+	}
 	if response.StatusCode >= 300 {
 		return obj, errorFromResponse("failed to save object", response, data)
 	}
@@ -200,6 +260,39 @@ func QMSave[T QMObject](ctx context.Context, proj *QMProject, obj T) (T, error)
 	return QMGet[T](ctx, proj, obj.Ref().Href)
 }
 
+// QMConflictError is returned if an object was modified on the server since
+// it was loaded. It contains the current version of the object, so the
+// changes can be merged and saved again.
+type QMConflictError[T QMObject] struct {
+	// Current version of the object on the server
+	Current T
+
+	// Err of the save request
+	Err error
+}
+
+func (e *QMConflictError[T]) Error() string {
+	return e.Err.Error()
+}
+
+func (e *QMConflictError[T]) Unwrap() error {
+	return e.Err
+}
+
+// qmConflictError for a save request that failed because the object was modified
+func qmConflictError[T QMObject](ctx context.Context, proj *QMProject, obj T, response *http.Response) error {
+	err := errorFromResponse("failed to save object: modified on server", response, nil)
+
+	current, getErr := QMGet[T](ctx, proj, obj.Ref().Href)
+	if getErr != nil {
+		return fmt.Errorf("%w (failed to load current version: %v)", err, getErr)
+	}
+	return &QMConflictError[T]{
+		Current: current,
+		Err:     err,
+	}
+}
+
 // UploadAttachment with the given file name and content
 func (p *QMProject) UploadAttachment(ctx context.Context, fileName string, fileReader io.Reader) (*QMAttachment, error) {
 	// get new UUID
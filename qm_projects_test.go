@@ -0,0 +1,239 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+)
+
+// qmTestProject creates a project on a server with the given handler
+func qmTestProject(t *testing.T, handler http.HandlerFunc) *QMProject {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL+"/", "user", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &QMProject{
+		Title: "Project",
+		Alias: "project",
+		qm:    client.QM,
+	}
+}
+
+// qmTestCaseHandler returns test cases with the title "TC <ID>" and fails
+// for IDs in failing
+func qmTestCaseHandler(failing map[string]bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := path.Base(r.URL.Path)
+		if failing[id] {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = fmt.Fprintf(w, `<ns2:testcase xmlns:ns2="http://jazz.net/xmlns/alm/qm/v0.1/"
+xmlns:ns3="http://purl.org/dc/elements/1.1/"><ns3:title>TC %s</ns3:title></ns2:testcase>`, id)
+	}
+}
+
+func TestQMGetOrderedList(t *testing.T) {
+	proj := qmTestProject(t, qmTestCaseHandler(nil))
+	proj.qm.client.Worker = 4
+
+	ids := make([]string, 20)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("tc%d", i+1)
+	}
+
+	testCases, err := qmGetOrderedList[*QMTestCase](context.Background(), proj, ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, testCase := range testCases {
+		if testCase.Title != "TC "+ids[i] {
+			t.Errorf("got test case %s at index %d, expected TC %s", testCase.Title, i, ids[i])
+		}
+	}
+}
+
+func TestQMGetOrderedListError(t *testing.T) {
+	tests := []struct {
+		name   string
+		worker int
+	}{
+		{"no worker", 0},
+		{"single worker", 1},
+		{"multiple worker", 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// all requests fail and there are more IDs than buffered
+			failing := make(map[string]bool)
+			ids := make([]string, 50)
+			for i := range ids {
+				ids[i] = fmt.Sprintf("tc%d", i+1)
+				failing[ids[i]] = true
+			}
+			proj := qmTestProject(t, qmTestCaseHandler(failing))
+			proj.qm.client.Worker = test.worker
+
+			_, err := qmGetOrderedList[*QMTestCase](context.Background(), proj, ids)
+			if err == nil || !strings.Contains(err.Error(), "failed to get testcase") {
+				t.Errorf("expected error of request, got %v", err)
+			}
+		})
+	}
+}
+
+func TestQMGetListChanError(t *testing.T) {
+	proj := qmTestProject(t, qmTestCaseHandler(map[string]bool{"tc2": true}))
+	proj.qm.client.Worker = 1
+
+	ids := make([]string, 50)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("tc%d", i+1)
+	}
+
+	testCases, err := qmGetList[*QMTestCase](context.Background(), proj, ids)
+	if err == nil {
+		t.Error("expected error of second test case")
+	}
+	if len(testCases) != 1 {
+		t.Errorf("expected stop after first error, got %d test cases", len(testCases))
+	}
+}
+
+// qmTestSaveHandler serves test case tc1 with the given ETag. Saves fail
+// with 412 if If-Match is not the ETag. The current version can not be
+// loaded if failingGet is set.
+func qmTestSaveHandler(etag string, failingGet bool, requests *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.Method+" "+r.Header.Get("If-Match"))
+
+		switch r.Method {
+		case "GET":
+			if failingGet {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = fmt.Fprintf(w, `<ns2:testcase xmlns:ns2="http://jazz.net/xmlns/alm/qm/v0.1/"
+xmlns:ns3="http://purl.org/dc/elements/1.1/"><ns3:identifier>%s</ns3:identifier>
+<ns3:title>TC %s</ns3:title></ns2:testcase>`, strings.TrimPrefix(r.URL.Path, "/"), etag)
+
+		case "PUT":
+			if r.Header.Get("If-Match") != etag {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				_, _ = fmt.Fprint(w, "modified")
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// qmTestSaveCase returns test case tc1 loaded with the given ETag
+func qmTestSaveCase(proj *QMProject, etag string) *QMTestCase {
+	testCase := &QMTestCase{Title: "Changed"}
+	testCase.SetProj(proj)
+	testCase.SetRef(testCase.Spec().GetURL(proj, "tc1"))
+	testCase.SetETag(etag)
+	return testCase
+}
+
+func TestQMSaveIfMatch(t *testing.T) {
+	var requests []string
+	proj := qmTestProject(t, qmTestSaveHandler("v1", false, &requests))
+
+	saved, err := QMSave(context.Background(), proj, qmTestSaveCase(proj, "v1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(requests, ","); got != "PUT v1,GET " {
+		t.Errorf("unexpected requests: %s", got)
+	}
+	if saved.ETag() != "v1" || saved.Title != "TC v1" {
+		t.Errorf("unexpected saved object: %s (%s)", saved.Title, saved.ETag())
+	}
+}
+
+func TestQMSaveConflict(t *testing.T) {
+	var requests []string
+	proj := qmTestProject(t, qmTestSaveHandler("v2", false, &requests))
+
+	_, err := QMSave(context.Background(), proj, qmTestSaveCase(proj, "v1"))
+
+	var conflict *QMConflictError[*QMTestCase]
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+	if conflict.Current == nil || conflict.Current.Title != "TC v2" || conflict.Current.ETag() != "v2" {
+		t.Errorf("unexpected current version: %+v", conflict.Current)
+	}
+	if !strings.Contains(err.Error(), "modified on server") {
+		t.Errorf("unexpected error message: %s", err)
+	}
+	var jazzErr *Error
+	if !errors.As(err, &jazzErr) || jazzErr.Details != "modified" {
+		t.Errorf("expected wrapped server error, got %v", err)
+	}
+	if got := strings.Join(requests, ","); got != "PUT v1,GET " {
+		t.Errorf("unexpected requests: %s", got)
+	}
+
+	// the current version can be saved
+	requests = nil
+	_, err = QMSave(context.Background(), proj, conflict.Current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(requests, ","); got != "PUT v2,GET " {
+		t.Errorf("unexpected requests: %s", got)
+	}
+}
+
+func TestQMSaveConflictLoadError(t *testing.T) {
+	var requests []string
+	proj := qmTestProject(t, qmTestSaveHandler("v2", true, &requests))
+
+	_, err := QMSave(context.Background(), proj, qmTestSaveCase(proj, "v1"))
+
+	var conflict *QMConflictError[*QMTestCase]
+	if errors.As(err, &conflict) {
+		t.Fatal("expected no conflict error without current version")
+	}
+	if err == nil || !strings.Contains(err.Error(), "modified on server") ||
+		!strings.Contains(err.Error(), "failed to load current version") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	var jazzErr *Error
+	if !errors.As(err, &jazzErr) || jazzErr.Details != "modified" {
+		t.Errorf("expected wrapped server error, got %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/beevik/etree"
+)
+
+// QMUpdate object loaded with QMGet. In contrast to QMSave the XML document
+// of the loaded object is kept and only the fields changed since loading are
+// replaced, so properties not available in the Go struct are not lost.
+// Only fields written by QMSave can be updated.
+func QMUpdate[T QMObject](ctx context.Context, proj *QMProject, obj T) (T, error) {
+	raw, ok := any(obj).(qmRawObject)
+	if !ok || len(raw.rawXml()) == 0 {
+		return obj, errors.New("failed to update object: object not loaded from server")
+	}
+
+	// find changed fields
+	tags, err := qmChangedFields(raw.rawXml(), obj)
+	if err != nil {
+		return obj, fmt.Errorf("failed to update object: %w", err)
+	}
+	if len(tags) == 0 {
+		return obj, nil
+	}
+
+	// merge changes in loaded document
+	dump, err := obj.Spec().DumpXml(obj)
+	if err != nil {
+		return obj, fmt.Errorf("failed to update object: %w", err)
+	}
+	data, err := qmMergeXml(raw.rawXml(), dump, tags)
+	if err != nil {
+		return obj, fmt.Errorf("failed to update object: %w", err)
+	}
+
+	// send request to server (only if the object was not modified since loaded)
+	response, err := proj.qm.client.putIfMatch(ctx, obj.Ref().Href, "application/xml", obj.ETag(), bytes.NewBuffer(data))
+	if err != nil {
+		return obj, fmt.Errorf("failed to update object: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusPreconditionFailed {
+		return obj, qmConflictError[T](ctx, proj, obj, response)
+	}
+	if response.StatusCode >= 300 {
+		return obj, errorFromResponse("failed to update object", response, data)
+	}
+
+	// load updated object from server
+	return QMGet[T](ctx, proj, obj.Ref().Href)
+}
+
+// qmChangedFields returns the names of the elements of all fields that differ
+// from the loaded XML document
+func qmChangedFields[T QMObject](raw []byte, obj T) ([]xml.Name, error) {
+	writable, ok := any(obj).(qmWritable)
+	if !ok {
+		return nil, errors.New("object can not be written")
+	}
+	document := reflect.TypeOf(writable.writeXml()).Elem()
+
+	var original T
+	err := xml.Unmarshal(raw, &original)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse loaded object: %w", err)
+	}
+
+	val := reflect.ValueOf(obj).Elem()
+	originalVal := reflect.ValueOf(original).Elem()
+	t := val.Type()
+
+	var names []xml.Name
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous || !field.IsExported() {
+			continue
+		}
+		if reflect.DeepEqual(val.Field(i).Interface(), originalVal.Field(i).Interface()) {
+			continue
+		}
+
+		name, ok := qmXmlName(document, field.Name)
+		if !ok {
+			return nil, fmt.Errorf("field %s can not be updated", field.Name)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// qmMergeXml replaces the elements with the given names in the raw document
+// with the elements of the dumped document. Elements are matched by
+// namespace URI and name, the replaced elements keep the namespace
+// declarations of the dumped document.
+func qmMergeXml(raw, dump []byte, names []xml.Name) ([]byte, error) {
+	doc := etree.NewDocument()
+	err := doc.ReadFromBytes(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse loaded object: %w", err)
+	}
+	dumpDoc := etree.NewDocument()
+	err = dumpDoc.ReadFromBytes(dump)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dumped object: %w", err)
+	}
+
+	root := doc.Root()
+	if root == nil || dumpDoc.Root() == nil {
+		return nil, errors.New("empty document")
+	}
+
+	for _, name := range names {
+		// remove existing elements
+		index := len(root.Child)
+		for _, element := range root.ChildElements() {
+			if element.Tag == name.Local && element.NamespaceURI() == name.Space {
+				if element.Index() < index {
+					index = element.Index()
+				}
+				root.RemoveChild(element)
+			}
+		}
+
+		// add dumped elements at position of the removed ones
+		for _, element := range dumpDoc.Root().ChildElements() {
+			if element.Tag != name.Local || element.NamespaceURI() != name.Space {
+				continue
+			}
+
+			root.InsertChildAt(index, qmDeclareNamespaces(element))
+			index++
+		}
+	}
+
+	return doc.WriteToBytes()
+}
+
+// qmDeclareNamespaces returns a copy of the element with the namespace
+// declarations of its parents, so it can be moved to another document
+func qmDeclareNamespaces(element *etree.Element) *etree.Element {
+	declared := make(map[string]bool)
+	for _, attr := range element.Attr {
+		if attr.Space == "xmlns" || (attr.Space == "" && attr.Key == "xmlns") {
+			declared[attr.FullKey()] = true
+		}
+	}
+
+	moved := element.Copy()
+	for parent := element.Parent(); parent != nil; parent = parent.Parent() {
+		for _, attr := range parent.Attr {
+			isDeclaration := attr.Space == "xmlns" || (attr.Space == "" && attr.Key == "xmlns")
+			if isDeclaration && !declared[attr.FullKey()] {
+				declared[attr.FullKey()] = true
+				moved.CreateAttr(attr.FullKey(), attr.Value)
+			}
+		}
+	}
+	return moved
+}
@@ -0,0 +1,228 @@
+// Copyright 2022 Benjamin Böhmke <benjamin@boehmke.net>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jazz
+
+import (
+	"context"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/beevik/etree"
+)
+
+// qmTestRawCase is a loaded test case with prefixes of the server and an
+// element unknown to QMTestCase
+const qmTestRawCase = `<ns2:testcase xmlns:ns2="http://jazz.net/xmlns/alm/qm/v0.1/" ` +
+	`xmlns:ns3="http://purl.org/dc/elements/1.1/">` +
+	`<ns3:title>Old</ns3:title>` +
+	`<ns2:webId>12</ns2:webId>` +
+	`<ns2:unknown>keep</ns2:unknown>` +
+	`<ns2:category term="A" value="1"/>` +
+	`<ns2:category term="B" value="2"/>` +
+	`<ns3:description>Description</ns3:description>` +
+	`</ns2:testcase>`
+
+func TestQMChangedFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(testCase *QMTestCase)
+		names  []xml.Name
+	}{
+		{"unchanged", func(testCase *QMTestCase) {}, nil},
+		{"title", func(testCase *QMTestCase) {
+			testCase.Title = "New"
+		}, []xml.Name{{Space: qmTestNsDC, Local: "title"}}},
+		{"multiple fields", func(testCase *QMTestCase) {
+			testCase.Description = ""
+			testCase.Categories[1].Value = "3"
+			testCase.Owner = "user"
+		}, []xml.Name{
+			{Space: qmTestNsDC, Local: "description"},
+			{Space: qmTestNsAlm, Local: "owner"},
+			{Space: qmTestNsQM, Local: "category"},
+		}},
+		{"base object", func(testCase *QMTestCase) {
+			testCase.ResourceUrl = "url"
+		}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var testCase *QMTestCase
+			err := xml.Unmarshal([]byte(qmTestRawCase), &testCase)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.change(testCase)
+
+			names, err := qmChangedFields([]byte(qmTestRawCase), testCase)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(names, test.names) {
+				t.Errorf("got changed fields %v, expected %v", names, test.names)
+			}
+		})
+	}
+}
+
+func TestQMChangedFieldsErrors(t *testing.T) {
+	// read-only fields can not be written
+	_, err := qmChangedFields([]byte(qmTestRawCase), &QMTestCase{Title: "Old", WebId: 13})
+	if err == nil || !strings.Contains(err.Error(), "WebId") {
+		t.Errorf("expected error for WebId, got %v", err)
+	}
+
+	_, err = qmChangedFields([]byte("<testcase>"), &QMTestCase{})
+	if err == nil {
+		t.Error("expected error for invalid document")
+	}
+
+	_, err = qmChangedFields([]byte("<attachment/>"), &QMAttachment{Title: "New"})
+	if err == nil {
+		t.Error("expected error for object that can not be written")
+	}
+}
+
+func TestQMMergeXml(t *testing.T) {
+	testCase := &QMTestCase{
+		Title:      "New & <b>",
+		Owner:      "user",
+		Design:     `<div xmlns="http://www.w3.org/1999/xhtml"><b>bold</b></div>`,
+		Categories: []QMCategory{{Name: "C", Value: "3"}},
+	}
+	dump, err := testCase.Spec().DumpXml(testCase)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		names    []xml.Name
+		expected string
+	}{
+		{
+			name:  "title",
+			names: []xml.Name{{Space: qmTestNsDC, Local: "title"}},
+			expected: `<ns2:testcase xmlns:ns2="http://jazz.net/xmlns/alm/qm/v0.1/" ` +
+				`xmlns:ns3="http://purl.org/dc/elements/1.1/">` +
+				`<title xmlns="http://purl.org/dc/elements/1.1/">New &amp; &lt;b&gt;</title>` +
+				`<ns2:webId>12</ns2:webId>` +
+				`<ns2:unknown>keep</ns2:unknown>` +
+				`<ns2:category term="A" value="1"/>` +
+				`<ns2:category term="B" value="2"/>` +
+				`<ns3:description>Description</ns3:description>` +
+				`</ns2:testcase>`,
+		},
+		{
+			name:  "list replaced at position",
+			names: []xml.Name{{Space: qmTestNsQM, Local: "category"}},
+			expected: `<ns2:testcase xmlns:ns2="http://jazz.net/xmlns/alm/qm/v0.1/" ` +
+				`xmlns:ns3="http://purl.org/dc/elements/1.1/">` +
+				`<ns3:title>Old</ns3:title>` +
+				`<ns2:webId>12</ns2:webId>` +
+				`<ns2:unknown>keep</ns2:unknown>` +
+				`<category xmlns="http://jazz.net/xmlns/alm/qm/v0.1/" term="C" value="3"/>` +
+				`<ns3:description>Description</ns3:description>` +
+				`</ns2:testcase>`,
+		},
+		{
+			name: "removed and added elements",
+			names: []xml.Name{
+				{Space: qmTestNsDC, Local: "description"},
+				{Space: qmTestNsAlm, Local: "owner"},
+				{Space: qmTestNsQM, Local: "com.ibm.rqm.planning.editor.section.testCaseDesign"},
+			},
+			expected: `<ns2:testcase xmlns:ns2="http://jazz.net/xmlns/alm/qm/v0.1/" ` +
+				`xmlns:ns3="http://purl.org/dc/elements/1.1/">` +
+				`<ns3:title>Old</ns3:title>` +
+				`<ns2:webId>12</ns2:webId>` +
+				`<ns2:unknown>keep</ns2:unknown>` +
+				`<ns2:category term="A" value="1"/>` +
+				`<ns2:category term="B" value="2"/>` +
+				`<owner xmlns="http://jazz.net/xmlns/alm/v0.1/">user</owner>` +
+				`<com.ibm.rqm.planning.editor.section.testCaseDesign xmlns="http://jazz.net/xmlns/alm/qm/v0.1/">` +
+				`<div xmlns="http://www.w3.org/1999/xhtml"><b>bold</b></div>` +
+				`</com.ibm.rqm.planning.editor.section.testCaseDesign>` +
+				`</ns2:testcase>`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := qmMergeXml([]byte(qmTestRawCase), dump, test.names)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.expected {
+				t.Errorf("got:\n%s\nexpected:\n%s", data, test.expected)
+			}
+		})
+	}
+}
+
+func TestQMMergeXmlPrefixes(t *testing.T) {
+	// namespaces declared in the root of the dump are kept for moved elements
+	const dump = `<qm:testcase xmlns:qm="http://jazz.net/xmlns/alm/qm/v0.1/" ` +
+		`xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>New</dc:title></qm:testcase>`
+
+	data, err := qmMergeXml([]byte(qmTestRawCase), []byte(dump), []xml.Name{{Space: qmTestNsDC, Local: "title"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc := etree.NewDocument()
+	err = doc.ReadFromBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	title := doc.Root().ChildElements()[0]
+	if qmTestName(title) != qmTestNsDC+" title" || title.Text() != "New" {
+		t.Errorf("got title %s with text %s", qmTestName(title), title.Text())
+	}
+}
+
+func TestQMMergeXmlErrors(t *testing.T) {
+	const dump = `<qm:testcase xmlns:qm="http://jazz.net/xmlns/alm/qm/v0.1/"/>`
+
+	tests := []struct {
+		name string
+		raw  string
+		dump string
+	}{
+		{"invalid document", "<testcase a=>", dump},
+		{"invalid dump", qmTestRawCase, "<testcase a=>"},
+		{"empty document", "", dump},
+		{"empty dump", qmTestRawCase, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := qmMergeXml([]byte(test.raw), []byte(test.dump), nil)
+			if err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestQMUpdateNotLoaded(t *testing.T) {
+	_, err := QMUpdate(context.Background(), &QMProject{}, &QMTestCase{Title: "New"})
+	if err == nil || !strings.Contains(err.Error(), "not loaded") {
+		t.Errorf("expected error for object not loaded from server, got %v", err)
+	}
+}